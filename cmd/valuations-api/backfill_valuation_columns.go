@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+)
+
+type backfillValuationColumnsCmd struct {
+	logger    zerolog.Logger
+	batchSvc  *services.BatchValuationService
+	batchSize int
+	dryRun    bool
+}
+
+func (*backfillValuationColumnsCmd) Name() string { return "backfill-valuation-columns" }
+func (*backfillValuationColumnsCmd) Synopsis() string {
+	return "backfill-valuation-columns projects the vendor json of existing valuations into the valuation columns"
+}
+func (*backfillValuationColumnsCmd) Usage() string {
+	return `backfill-valuation-columns [-batch 500] [-dry-run]`
+}
+
+func (p *backfillValuationColumnsCmd) SetFlags(f *flag.FlagSet) {
+	f.IntVar(&p.batchSize, "batch", 500, "rows to read and update at a time")
+	f.BoolVar(&p.dryRun, "dry-run", false, "only count the rows that would be updated")
+}
+
+func (p *backfillValuationColumnsCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	updated, err := p.batchSvc.BackfillValuationColumns(ctx, p.batchSize, p.dryRun)
+	if err != nil {
+		p.logger.Err(err).Int("updated", updated).Msg("failed to backfill valuation columns")
+		return subcommands.ExitFailure
+	}
+	if p.dryRun {
+		fmt.Printf("dry run, %d valuations would be backfilled\n", updated)
+	} else {
+		fmt.Printf("%d valuations backfilled\n", updated)
+	}
+
+	return subcommands.ExitSuccess
+}
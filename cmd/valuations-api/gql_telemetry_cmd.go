@@ -48,21 +48,21 @@ func (p *gqlTelemetryCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...int
 	p.logger.Info().Msgf("jwt: %s", p.jwt)
 
 	if p.command == "vehicle" {
-		vehicle, err := p.identity.GetVehicle(tokenID)
+		vehicle, err := p.identity.GetVehicle(ctx, tokenID)
 		if err != nil {
 			p.logger.Fatal().Err(err).Msg("could not get vehicle")
 		}
 		p.logger.Info().Msgf("vehicle: %+v", vehicle)
 	}
 	if p.command == "telemetry" {
-		signals, err := p.telemetry.GetLatestSignals(tokenID, "Bearer "+p.jwt)
+		signals, err := p.telemetry.GetLatestSignals(ctx, tokenID, "Bearer "+p.jwt)
 		if err != nil {
 			p.logger.Fatal().Err(err).Msg("could not get latest signals")
 		}
 		p.logger.Info().Msgf("signals: %+v", signals)
 	}
 	if p.command == "location" {
-		signals, err := p.telemetry.GetLatestSignals(tokenID, "Bearer "+p.jwt)
+		signals, err := p.telemetry.GetLatestSignals(ctx, tokenID, "Bearer "+p.jwt)
 		if err != nil {
 			p.logger.Fatal().Err(err).Msg("could not get latest signals")
 		}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+)
+
+type loadFxRatesCmd struct {
+	logger zerolog.Logger
+	fx     services.FxRateService
+	file   string
+}
+
+func (*loadFxRatesCmd) Name() string { return "load-fx-rates" }
+func (*loadFxRatesCmd) Synopsis() string {
+	return "load-fx-rates loads ECB euro reference exchange rates from a csv or xml file into the fx_rates table"
+}
+func (*loadFxRatesCmd) Usage() string {
+	return `load-fx-rates -file <eurofxref-hist.csv|eurofxref-daily.xml>`
+}
+
+func (p *loadFxRatesCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.file, "file", "", "ECB rates file, csv or xml as downloaded from the ECB website")
+}
+
+func (p *loadFxRatesCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if p.file == "" {
+		p.logger.Error().Msg("-file is required")
+		return subcommands.ExitUsageError
+	}
+	f, err := os.Open(p.file)
+	if err != nil {
+		p.logger.Err(err).Msgf("failed to open %s", p.file)
+		return subcommands.ExitFailure
+	}
+	defer f.Close() //nolint:errcheck
+
+	var rates []*models.FxRate
+	switch strings.ToLower(filepath.Ext(p.file)) {
+	case ".csv":
+		rates, err = services.ParseECBCSV(f)
+	case ".xml":
+		rates, err = services.ParseECBXML(f)
+	default:
+		p.logger.Error().Msgf("unsupported rates file %s, must be .csv or .xml", p.file)
+		return subcommands.ExitUsageError
+	}
+	if err != nil {
+		p.logger.Err(err).Msgf("failed to parse %s", p.file)
+		return subcommands.ExitFailure
+	}
+
+	if err := p.fx.SaveRates(ctx, rates); err != nil {
+		p.logger.Err(err).Msg("failed to save rates")
+		return subcommands.ExitFailure
+	}
+	fmt.Printf("loaded %d rates from %s\n", len(rates), p.file)
+
+	return subcommands.ExitSuccess
+}
@@ -56,31 +56,68 @@ func main() {
 		time.Sleep(time.Second)
 		totalTime++
 	}
-	identityAPI := gateways.NewIdentityAPIService(&logger, &cfg)
+	identityAPI, err := gateways.NewCachedIdentityAPI(gateways.NewIdentityAPIService(&logger, &cfg), &cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid identity cache settings")
+	}
 	telemetryAPI := gateways.NewTelemetryAPI(&logger, &cfg)
 	locationSvc := services.NewLocationService(pdb.DBS, &cfg, &logger)
 	devicesConn, err := grpc.NewClient(cfg.DevicesGRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to dial devices grpc")
 	}
-	userDeviceSvc := services.NewUserDeviceService(devicesConn, pdb.DBS, &logger, locationSvc, telemetryAPI)
-
+	blending, err := services.NewPriceBlending(&cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid price blending settings")
+	}
+	batteryCurve, err := services.NewBatteryAdjustmentCurve(&cfg)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid EV_BATTERY_ADJUSTMENT_CURVE")
+	}
+	// order of registration is order of preference when more than one provider supports a country
+	providers := services.NewValuationProviderRegistry(
+		services.NewDrivlyValuationService(pdb.DBS, &logger, &cfg, identityAPI, locationSvc, blending, batteryCurve),
+		services.NewVincarioValuationService(pdb.DBS, &logger, &cfg, identityAPI, locationSvc, blending, batteryCurve),
+	)
+	valuationRouting, err := services.ParseProviderRouting(cfg.ValuationProviderRouting)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid VALUATION_PROVIDER_ROUTING")
+	}
+	offerRouting, err := services.ParseProviderRouting(cfg.OfferProviderRouting)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid OFFER_PROVIDER_ROUTING")
+	}
+	if err := providers.SetRouting(valuationRouting, offerRouting); err != nil {
+		logger.Fatal().Err(err).Msg("invalid provider routing")
+	}
+	fx := services.NewFxRateService(pdb.DBS)
+	userDeviceSvc := services.NewUserDeviceService(devicesConn, pdb.DBS, &logger, locationSvc, telemetryAPI, providers, fx)
 	defer devicesConn.Close()
 
 	subcommands.Register(subcommands.HelpCommand(), "")
 	subcommands.Register(subcommands.FlagsCommand(), "")
 	subcommands.Register(subcommands.CommandsCommand(), "")
 	subcommands.Register(&migrateDBCmd{logger: logger, settings: cfg}, "")
-	subcommands.Register(&loadValuationsCmd{logger: logger,
-		settings: cfg,
-		pdb:      pdb,
+	batchSvc := services.NewBatchValuationService(pdb.DBS, &logger, locationSvc, providers)
+	subcommands.Register(&loadValuationsCmd{logger: logger, batchSvc: batchSvc}, "")
+	subcommands.Register(&backfillValuationColumnsCmd{logger: logger, batchSvc: batchSvc}, "")
+	subcommands.Register(&workerCmd{logger: logger, settings: &cfg, providers: providers, telemetry: telemetryAPI}, "")
+	subcommands.Register(&loadFxRatesCmd{logger: logger, fx: fx}, "")
+	subcommands.Register(&snapshotValuationsCmd{logger: logger,
+		analytics: services.NewNetworkAnalyticsService(pdb.DBS, &logger, providers, fx),
 	}, "")
 	subcommands.Register(&gqlTelemetryCmd{logger: logger, telemetry: telemetryAPI, identity: identityAPI, settings: &cfg, dbs: pdb.DBS},
 		"")
 
 	// Run API
 	if len(os.Args) == 1 {
-		app.Run(ctx, pdb, logger, &cfg, identityAPI, userDeviceSvc, telemetryAPI, locationSvc)
+		// only the api and the worker queue jobs, other subcommands run without nats
+		natsSvc, err := services.NewNATSService(&cfg, &logger)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to setup nats")
+		}
+		jobs := services.NewValuationJobService(natsSvc, providers, telemetryAPI, &logger)
+		app.Run(ctx, pdb, logger, &cfg, identityAPI, userDeviceSvc, telemetryAPI, locationSvc, providers, jobs, fx, natsSvc)
 	} else {
 		flag.Parse()
 		os.Exit(int(subcommands.Execute(ctx)))
@@ -3,37 +3,53 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"strings"
 
-	"github.com/DIMO-Network/shared/pkg/db"
-	"github.com/DIMO-Network/valuations-api/internal/config"
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
 	"github.com/google/subcommands"
 	"github.com/rs/zerolog"
 )
 
 type loadValuationsCmd struct {
-	logger   zerolog.Logger
-	settings config.Settings
-	pdb      db.Store
-	wmi      string
+	logger      zerolog.Logger
+	batchSvc    *services.BatchValuationService
+	wmi         string
+	concurrency int
+	dryRun      bool
 }
 
 func (*loadValuationsCmd) Name() string { return "pull-valuations" }
 func (*loadValuationsCmd) Synopsis() string {
-	return "pull-valuations runs through all connected cars and gets their valuation"
+	return "pull-valuations runs through all previously valued cars and pulls a new valuation for those past the provider repull window"
 }
 func (*loadValuationsCmd) Usage() string {
-	return `pull-valuations -wmi <WMI 3 char>`
+	return `pull-valuations [-wmi <WMI 3 char,...>] [-concurrency 5] [-dry-run]`
 }
 
 func (p *loadValuationsCmd) SetFlags(f *flag.FlagSet) {
-	f.StringVar(&p.wmi, "wmi", "", "WMI filter option to only get valuations for these")
+	f.StringVar(&p.wmi, "wmi", "", "WMI filter option to only get valuations for these, comma separated")
+	f.IntVar(&p.concurrency, "concurrency", 5, "max valuations to pull at the same time")
+	f.BoolVar(&p.dryRun, "dry-run", false, "only count the valuations that would be pulled")
 }
 
-func (p *loadValuationsCmd) Execute(_ context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
-	p.logger.Info().Msgf("Pull VIN info, valuations and pricing from driv.ly for USA and valuations from Vincario for EUR")
-
-	// todo pending implement, re-think business / product value. This should be taken over by DINC.
-	// Send a notification when new valuation comes up. Run valuations every 3 months.
+func (p *loadValuationsCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	opts := services.BatchPullOptions{
+		Concurrency: p.concurrency,
+		DryRun:      p.dryRun,
+	}
+	if p.wmi != "" {
+		opts.WMIs = strings.Split(p.wmi, ",")
+	}
+	p.logger.Info().Msgf("pulling valuations for vehicles due a repull. wmi: %v dry run: %t", opts.WMIs, opts.DryRun)
+
+	summary, err := p.batchSvc.PullValuations(ctx, opts)
+	if err != nil {
+		p.logger.Err(err).Msg("failed to pull valuations")
+		return subcommands.ExitFailure
+	}
+	fmt.Printf("vehicles: %d pulled: %d skipped: %d no provider: %d errored: %d\n",
+		summary.Vehicles, summary.Pulled, summary.Skipped, summary.NoProvider, summary.Errored)
 
 	return subcommands.ExitSuccess
 }
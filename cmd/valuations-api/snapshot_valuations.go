@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+)
+
+type snapshotValuationsCmd struct {
+	logger    zerolog.Logger
+	analytics services.NetworkAnalyticsService
+	date      string
+	days      int
+}
+
+func (*snapshotValuationsCmd) Name() string { return "snapshot-valuations" }
+func (*snapshotValuationsCmd) Synopsis() string {
+	return "snapshot-valuations takes the daily network valuation snapshot, replacing any existing one. Use -days to backfill"
+}
+func (*snapshotValuationsCmd) Usage() string {
+	return `snapshot-valuations [-date YYYY-MM-DD] [-days 1]`
+}
+
+func (p *snapshotValuationsCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&p.date, "date", "", "day to snapshot, default today")
+	f.IntVar(&p.days, "days", 1, "number of days to snapshot, going back from date")
+}
+
+func (p *snapshotValuationsCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	day := time.Now().UTC()
+	if p.date != "" {
+		var err error
+		day, err = time.Parse(time.DateOnly, p.date)
+		if err != nil {
+			p.logger.Err(err).Msg("invalid -date, expected YYYY-MM-DD")
+			return subcommands.ExitUsageError
+		}
+	}
+	for i := 0; i < p.days; i++ {
+		d := day.AddDate(0, 0, -i)
+		if err := p.analytics.TakeSnapshot(ctx, d); err != nil {
+			p.logger.Err(err).Msgf("failed to snapshot %s", d.Format(time.DateOnly))
+			return subcommands.ExitFailure
+		}
+		fmt.Printf("snapshot taken for %s\n", d.Format(time.DateOnly))
+	}
+
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
+	"github.com/google/subcommands"
+	"github.com/rs/zerolog"
+)
+
+type workerCmd struct {
+	logger    zerolog.Logger
+	settings  *config.Settings
+	providers *services.ValuationProviderRegistry
+	telemetry gateways.TelemetryAPI
+}
+
+func (*workerCmd) Name() string { return "worker" }
+func (*workerCmd) Synopsis() string {
+	return "worker consumes the queued valuation and instant offer jobs"
+}
+func (*workerCmd) Usage() string {
+	return `worker`
+}
+
+func (p *workerCmd) SetFlags(_ *flag.FlagSet) {}
+
+func (p *workerCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	natsSvc, err := services.NewNATSService(p.settings, &p.logger)
+	if err != nil {
+		p.logger.Err(err).Msg("failed to setup nats")
+		return subcommands.ExitFailure
+	}
+	jobs := services.NewValuationJobService(natsSvc, p.providers, p.telemetry, &p.logger)
+
+	p.logger.Info().Msg("starting valuation jobs worker")
+	if err := jobs.RunWorker(ctx); err != nil {
+		p.logger.Err(err).Msg("valuation jobs worker failed")
+		return subcommands.ExitFailure
+	}
+	p.logger.Info().Msg("valuation jobs worker stopped")
+
+	return subcommands.ExitSuccess
+}
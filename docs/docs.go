@@ -15,6 +15,45 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/v2/owners/{address}/valuations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "gets the latest valuation of every vehicle owned by the address, with the total value of the portfolio.\nVehicles never valued get an estimate from the valued ones, included in estimatedTotalValue.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "valuations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "owner ethereum address, must be the address of the authenticated user",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert values to, default USD",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.OwnerPortfolio"
+                        }
+                    }
+                }
+            }
+        },
         "/v2/vehicles/{tokenId}/instant-offer": {
             "post": {
                 "security": [
@@ -22,7 +61,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "makes a request for an instant offer for a particular user device. Simply returns success if able to create job.\nYou will need to query the offers endpoint to see if a new offer showed up. Job can take about a minute to complete.",
+                "description": "makes a request for an instant offer for a particular user device. Queues a job and returns its id, poll the\njobs endpoint to know when it completes, then query the offers endpoint. Job can take about a minute to complete.",
                 "produces": [
                     "application/json"
                 ],
@@ -40,7 +79,50 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.JobQueuedResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/vehicles/{tokenId}/jobs/{jobId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "gets the status of a valuation or instant offer job. Status is Queued or Processing until the job completes,\nthen the pull result eg. PulledValuations or Skipped, or Error.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "valuations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tokenId for vehicle the job was requested for",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job id returned when requesting the valuation or instant offer",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.ValuationJob"
+                        }
                     }
                 }
             }
@@ -52,7 +134,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "gets any existing offers for a particular user device. You must call instant-offer endpoint first to pull newer. Returns list.",
+                "description": "gets all existing offers for a particular user device, newest first. You must call instant-offer endpoint first to pull newer.\nOffers expire after 7 days, check expired and request a new instant offer to get working offer links.",
                 "produces": [
                     "application/json"
                 ],
@@ -66,6 +148,12 @@ const docTemplate = `{
                         "name": "tokenId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "km or mi to convert mileage to. Defaults to mi",
+                        "name": "units",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -85,7 +173,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "request valuation only from drivly. Currently USA Only",
+                "description": "request valuation only, no offers. The valuation provider is chosen by the country the vehicle is located in.\nQueues a job and returns its id, poll the jobs endpoint to know when it completes.",
                 "produces": [
                     "application/json"
                 ],
@@ -103,7 +191,10 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controllers.JobQueuedResponse"
+                        }
                     }
                 }
             }
@@ -129,6 +220,18 @@ const docTemplate = `{
                         "name": "tokenId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency to convert prices to, eg. USD. Defaults to the vendor currency",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "km or mi to convert mileage and odometer to. Defaults to the vendor unit",
+                        "name": "units",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -140,14 +243,111 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/v2/vehicles/{tokenId}/valuations/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "gets every valuation pulled for a particular vehicle over time, newest first, so the value can be charted.\nPaginated, pass the nextCursor from the response as the cursor param to get the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "valuations"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tokenId for vehicle to get valuations",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only valuations created at or after this time, RFC3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "only valuations created before this time, RFC3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size, default 50, max 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "km or mi to convert mileage and odometer to. Defaults to the vendor unit",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.ValuationHistory"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "github_com_DIMO-Network_valuations-api_internal_core_models.ConditionTier": {
+            "type": "string",
+            "enum": [
+                "clean",
+                "average",
+                "rough"
+            ],
+            "x-enum-varnames": [
+                "CleanCondition",
+                "AverageCondition",
+                "RoughCondition"
+            ]
+        },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.DataPullStatusEnum": {
+            "type": "string",
+            "enum": [
+                "PulledAll",
+                "PulledValuations",
+                "PulledValuationVincario",
+                "Skipped",
+                "Queued",
+                "Processing",
+                "Error"
+            ],
+            "x-enum-varnames": [
+                "PulledInfoAndValuationStatus",
+                "PulledValuationDrivlyStatus",
+                "PulledValuationVincarioStatus",
+                "SkippedDataPullStatus",
+                "QueuedDataPullStatus",
+                "ProcessingDataPullStatus",
+                "ErrorDataPullStatus"
+            ]
+        },
         "github_com_DIMO-Network_valuations-api_internal_core_models.DeviceOffer": {
             "type": "object",
             "properties": {
                 "offerSets": {
-                    "description": "Contains a list of offer sets, one for each source",
+                    "description": "Contains a list of offer sets, newest first",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.OfferSet"
@@ -167,6 +367,30 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.JobType": {
+            "type": "string",
+            "enum": [
+                "valuation",
+                "offer"
+            ],
+            "x-enum-varnames": [
+                "ValuationJobType",
+                "OfferJobType"
+            ]
+        },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.MileageSource": {
+            "type": "string",
+            "enum": [
+                "telemetry",
+                "extrapolated",
+                "country_average"
+            ],
+            "x-enum-varnames": [
+                "TelemetryMileage",
+                "ExtrapolatedMileage",
+                "CountryAverageMileage"
+            ]
+        },
         "github_com_DIMO-Network_valuations-api_internal_core_models.OdometerMeasurementEnum": {
             "type": "string",
             "enum": [
@@ -212,6 +436,14 @@ const docTemplate = `{
         "github_com_DIMO-Network_valuations-api_internal_core_models.OfferSet": {
             "type": "object",
             "properties": {
+                "expired": {
+                    "description": "Whether the offers have expired",
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "description": "When the offers expire, offer URLs no longer work after this and a new instant offer must be requested",
+                    "type": "string"
+                },
                 "mileage": {
                     "description": "The mileage used for the offers",
                     "type": "integer"
@@ -224,6 +456,10 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "odometerUnit": {
+                    "description": "OdometerUnit km or miles, the unit of mileage",
+                    "type": "string"
+                },
                 "offers": {
                     "description": "Contains a list of offers from the source",
                     "type": "array",
@@ -245,9 +481,159 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.OwnerPortfolio": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency all values are converted to, eg. USD",
+                    "type": "string"
+                },
+                "estimatedTotalValue": {
+                    "description": "EstimatedTotalValue TotalValue plus EstimatedUnvaluedValue",
+                    "type": "integer"
+                },
+                "estimatedUnvaluedValue": {
+                    "description": "EstimatedUnvaluedValue estimate for the unvalued vehicles, from the valued vehicles of the same definition when\nthere are any, otherwise the average of all the valued vehicles",
+                    "type": "integer"
+                },
+                "owner": {
+                    "type": "string"
+                },
+                "totalValue": {
+                    "description": "TotalValue sum of the valued vehicles",
+                    "type": "integer"
+                },
+                "unvaluedCount": {
+                    "description": "UnvaluedCount vehicles we have never valued, or whose valuation could not be converted to the currency",
+                    "type": "integer"
+                },
+                "valuedCount": {
+                    "description": "ValuedCount vehicles with a valuation",
+                    "type": "integer"
+                },
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.PortfolioVehicle"
+                    }
+                }
+            }
+        },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.PortfolioVehicle": {
+            "type": "object",
+            "properties": {
+                "definitionId": {
+                    "type": "string"
+                },
+                "estimated": {
+                    "description": "Estimated true if the value is the fill-in estimate, not a valuation",
+                    "type": "boolean"
+                },
+                "make": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "description": "Updated when the latest valuation was pulled",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value the user display price of the latest valuation, converted to the portfolio currency",
+                    "type": "integer"
+                },
+                "valued": {
+                    "type": "boolean"
+                },
+                "vendor": {
+                    "description": "Vendor the source of the latest valuation",
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.ValuationHistory": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "description": "Pass as the cursor param to get the next page, empty if there are no more valuations",
+                    "type": "string"
+                },
+                "valuationSets": {
+                    "description": "Valuation sets over time, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.ValuationSet"
+                    }
+                }
+            }
+        },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.ValuationJob": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Set when the status is Error",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Queued, Processing, or the pull result eg. PulledValuations, Skipped, Error",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.DataPullStatusEnum"
+                        }
+                    ]
+                },
+                "tokenId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "valuation or offer. Offer requests for countries without instant offers are queued as a valuation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.JobType"
+                        }
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "github_com_DIMO-Network_valuations-api_internal_core_models.ValuationSet": {
             "type": "object",
             "properties": {
+                "batteryAdjustment": {
+                    "description": "BatteryAdjustment amount userDisplayPrice was adjusted by for the EV battery health, negative for a degraded\nbattery. The other prices are adjusted by the same percent, the book values in the sources are not",
+                    "type": "integer"
+                },
+                "batteryStateOfHealth": {
+                    "description": "BatteryStateOfHealth percent of the original EV battery capacity, when known",
+                    "type": "number"
+                },
+                "blendingStrategy": {
+                    "description": "BlendingStrategy how userDisplayPrice was blended from the vendor prices, eg. vendor, mean or median. Empty for\nvaluations from before strategies were recorded, which used vendor",
+                    "type": "string"
+                },
+                "conditionTier": {
+                    "description": "ConditionTier clean, average or rough, scored from the vehicle's diagnostic signals. Selects the drivly tier used\nfor tradeIn and retail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.ConditionTier"
+                        }
+                    ]
+                },
                 "currency": {
                     "description": "eg. USD or EUR",
                     "type": "string"
@@ -256,6 +642,14 @@ const docTemplate = `{
                     "description": "The mileage used for the valuation",
                     "type": "integer"
                 },
+                "mileageSource": {
+                    "description": "MileageSource telemetry when the mileage is the vehicle odometer, or how it was estimated: extrapolated or\ncountry_average. Empty for valuations from before it was recorded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.MileageSource"
+                        }
+                    ]
+                },
                 "odometer": {
                     "description": "Odometer used for calculating values",
                     "type": "integer"
@@ -269,10 +663,11 @@ const docTemplate = `{
                     ]
                 },
                 "odometerUnit": {
+                    "description": "OdometerUnit km or miles, the unit of mileage and odometer",
                     "type": "string"
                 },
                 "retail": {
-                    "description": "retail is equal to retailAverage when available",
+                    "description": "retail is the value in the vehicle's condition tier, equal to retailAverage when the condition is unknown",
                     "type": "integer"
                 },
                 "retailAverage": {
@@ -286,11 +681,18 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "retailSource": {
-                    "description": "Useful when Drivly returns multiple vendors and we've selected one (eg. \"drivly:blackbook\")",
+                    "description": "The vendor, for drivly with the books averaged into the price (eg. \"drivly:blackbook,kbb\")",
                     "type": "string"
                 },
+                "retailSources": {
+                    "description": "RetailSources the book values drivly returned for retail, retail is the average of those included",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.ValuationSource"
+                    }
+                },
                 "tradeIn": {
-                    "description": "tradeIn is equal to tradeInAverage when available",
+                    "description": "tradeIn is the value in the vehicle's condition tier, equal to tradeInAverage when the condition is unknown",
                     "type": "integer"
                 },
                 "tradeInAverage": {
@@ -304,9 +706,16 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "tradeInSource": {
-                    "description": "Useful when Drivly returns multiple vendors and we've selected one (eg. \"drivly:blackbook\")",
+                    "description": "The vendor, for drivly with the books averaged into the price (eg. \"drivly:blackbook,kbb\")",
                     "type": "string"
                 },
+                "tradeInSources": {
+                    "description": "TradeInSources the book values drivly returned for trade-in, tradeIn is the average of those included",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_valuations-api_internal_core_models.ValuationSource"
+                    }
+                },
                 "updated": {
                     "description": "The time the valuation was pulled or in the case of blackbook, this may be the event time of the device odometer which was used for the valuation",
                     "type": "string"
@@ -324,6 +733,42 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "github_com_DIMO-Network_valuations-api_internal_core_models.ValuationSource": {
+            "type": "object",
+            "properties": {
+                "clean": {
+                    "description": "Clean and Rough condition tiers, when the book has them",
+                    "type": "integer"
+                },
+                "included": {
+                    "description": "Included whether the value was used in the blended price, values of 100 or less are ignored",
+                    "type": "boolean"
+                },
+                "rough": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source name of the book, eg. blackbook, kbb, edmunds, nada or cargurus",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value the book's value, used in the blended price",
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_controllers.JobQueuedResponse": {
+            "type": "object",
+            "properties": {
+                "jobId": {
+                    "description": "poll the jobs endpoint with this id for the job status",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -4,8 +4,7 @@ import (
 	"context"
 	"net"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 
 	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
 
@@ -34,74 +33,163 @@ import (
 
 	fiberrecover "github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/swagger"
+	"github.com/pkg/errors"
 
 	// docs are generated by Swag CLI, you have to import them.
 	_ "github.com/DIMO-Network/valuations-api/docs"
 )
 
 func Run(ctx context.Context, pdb db.Store, logger zerolog.Logger, settings *config.Settings, identity gateways.IdentityAPI,
-	userDeviceSvc services.UserDeviceAPIService, telemetry gateways.TelemetryAPI, locationSvc services.LocationService) {
+	userDeviceSvc services.UserDeviceAPIService, telemetry gateways.TelemetryAPI, locationSvc services.LocationService,
+	providers *services.ValuationProviderRegistry, jobs services.ValuationJobService, fx services.FxRateService,
+	natsSvc *services.NATSService) {
 
-	startMonitoringServer(logger, settings)
-	go startGRCPServer(pdb, logger, settings, userDeviceSvc)
+	lc, err := NewLifecycle(ctx, logger, settings.ShutdownTimeout, settings.ShutdownDrainDelay)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("invalid shutdown settings")
+	}
 
-	drivlySvc := services.NewDrivlyValuationService(pdb.DBS, &logger, settings)
-	vincarioSvc := services.NewVincarioValuationService(pdb.DBS, &logger, settings, identity)
-	app := startWebAPI(logger, settings, userDeviceSvc, drivlySvc, vincarioSvc, identity, telemetry, locationSvc)
-	// nolint
-	defer app.Shutdown()
+	portfolio := services.NewPortfolioService(pdb.DBS, &logger, identity, providers, fx)
+
+	// started first so it is stopped last, /health reports not ready while the rest drains
+	ready := newReadiness(
+		DependencyCheck{Name: "postgres", Critical: true, Check: func(ctx context.Context) error {
+			if !pdb.IsReady() {
+				return errors.New("not connected")
+			}
+			return pdb.DBS().Reader.PingContext(ctx)
+		}},
+		DependencyCheck{Name: "identity-api", Check: identity.Ping},
+		DependencyCheck{Name: "telemetry-api", Check: telemetry.Ping},
+		DependencyCheck{Name: "nats", Check: natsSvc.Ping},
+	)
+	startMonitoringServer(lc, logger, settings, ready, newMigrationsApplied(pdb.DBS().Reader.DB))
+	startRevaluationScheduler(lc, pdb, logger, settings, locationSvc, providers)
+	startGRCPServer(lc, pdb, logger, settings, userDeviceSvc, providers, fx, portfolio)
+	startWebAPI(lc, logger, settings, userDeviceSvc, providers, identity, telemetry, locationSvc, jobs, portfolio)
 
-	c := make(chan os.Signal, 1)                    // Create channel to signify a signal being sent with length of 1
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM) // When an interrupt or termination signal is sent, notify the channel
-	<-c                                             // This blocks the main thread until an interrupt is received
+	failure := lc.Wait()
+	if failure != nil {
+		logger.Err(failure).Msg("server failed, shutting down")
+	}
 	logger.Info().Msg("Gracefully shutting down and running cleanup tasks...")
-	_ = ctx.Done()
+	if err := lc.Shutdown(); err != nil {
+		logger.Err(err).Msg("shutdown did not complete before the deadline")
+	}
+	if failure != nil {
+		os.Exit(1)
+	}
+}
+
+// startRevaluationScheduler starts re-pulling stale valuations in the background if REVALUATION_INTERVAL is set
+func startRevaluationScheduler(lc *Lifecycle, pdb db.Store, logger zerolog.Logger, settings *config.Settings,
+	locationSvc services.LocationService, providers *services.ValuationProviderRegistry) {
+	if settings.RevaluationInterval == "" {
+		return
+	}
+	interval, err := time.ParseDuration(settings.RevaluationInterval)
+	if err != nil {
+		logger.Fatal().Err(err).Msgf("invalid REVALUATION_INTERVAL %s", settings.RevaluationInterval)
+	}
+	batchSvc := services.NewBatchValuationService(pdb.DBS, &logger, locationSvc, providers)
+	lc.Go("revaluation scheduler", services.NewRevaluationScheduler(pdb.DBS, &logger, batchSvc, interval, settings.RevaluationConcurrency).Start)
 }
 
 // startMonitoringServer start server for monitoring endpoints. Could likely be moved to shared lib.
-func startMonitoringServer(logger zerolog.Logger, settings *config.Settings) {
+func startMonitoringServer(lc *Lifecycle, logger zerolog.Logger, settings *config.Settings, ready *readiness,
+	migrations *migrationsApplied) {
 	monApp := fiber.New(fiber.Config{DisableStartupMessage: true})
 	monApp.Get("/health", func(c *fiber.Ctx) error {
+		if !lc.Ready() {
+			return c.Status(fiber.StatusServiceUnavailable).SendString("shutting down")
+		}
 		return c.Status(fiber.StatusOK).SendString("healthy")
 	})
 
+	// readiness probe, not ready while shutting down or a critical dependency is unreachable
+	monApp.Get("/ready", func(c *fiber.Ctx) error {
+		report := ready.Report(c.Context())
+		if !lc.Ready() {
+			report.Ready = false
+		}
+		if !report.Ready {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+		}
+		return c.JSON(report)
+	})
+	// startup probe, fails until the database migrations this version needs are applied
+	monApp.Get("/startup", func(c *fiber.Ctx) error {
+		if err := migrations.Check(c.Context()); err != nil {
+			return c.Status(fiber.StatusServiceUnavailable).SendString(err.Error())
+		}
+		return c.SendString("started")
+	})
+
 	monApp.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	go func() {
 		// 8888 is our standard port for exposing metrics in DIMO infra
 		if err := monApp.Listen(":" + settings.MonitoringPort); err != nil {
-			logger.Fatal().Err(err).Str("port", settings.MonitoringPort).Msg("Failed to start monitoring web server.")
+			lc.Fail(errors.Wrapf(err, "failed to start monitoring web server on port %s", settings.MonitoringPort))
 		}
 	}()
+	lc.OnStop("monitoring web server", monApp.ShutdownWithContext)
 
 	logger.Info().Str("port", "8888").Msg("Started monitoring web server.")
 }
 
-func startGRCPServer(pdb db.Store, logger zerolog.Logger, settings *config.Settings, userDeviceSvc services.UserDeviceAPIService) {
+func startGRCPServer(lc *Lifecycle, pdb db.Store, logger zerolog.Logger, settings *config.Settings, userDeviceSvc services.UserDeviceAPIService,
+	providers *services.ValuationProviderRegistry, fx services.FxRateService, portfolio services.PortfolioService) {
 	lis, err := net.Listen("tcp", ":"+settings.GRPCPort)
 	if err != nil {
-		logger.Fatal().Err(err).Msgf("Couldn't listen on gRPC port %s", settings.GRPCPort)
+		lc.Fail(errors.Wrapf(err, "couldn't listen on gRPC port %s", settings.GRPCPort))
+		return
 	}
 
 	logger.Info().Msgf("Starting gRPC server on port %s", settings.GRPCPort)
 	server := grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			metrics.GRPCMetricsAndLogMiddleware(&logger),
+			rpc.ServiceAuthInterceptor([]byte(settings.GRPCServiceJWTSecret), settings.ServiceName,
+				pb.ValuationsService_GetUserDeviceValuation_FullMethodName, pb.ValuationsService_GetOwnerValuations_FullMethodName),
 			grpc_ctxtags.UnaryServerInterceptor(),
 			grpc_prometheus.UnaryServerInterceptor,
 		)),
 		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
 	)
-	pb.RegisterValuationsServiceServer(server, rpc.NewValuationsService(pdb.DBS, &logger, userDeviceSvc))
+	pb.RegisterValuationsServiceServer(server, rpc.NewValuationsService(&logger, userDeviceSvc, portfolio,
+		services.NewNetworkAnalyticsService(pdb.DBS, &logger, providers, fx)))
 
-	if err := server.Serve(lis); err != nil {
-		logger.Fatal().Err(err).Msg("gRPC server terminated unexpectedly")
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			lc.Fail(errors.Wrap(err, "gRPC server terminated unexpectedly"))
+		}
+	}()
+	lc.OnStop("gRPC server", func(ctx context.Context) error {
+		return gracefulStop(ctx, server)
+	})
+}
+
+// gracefulStop waits for in flight rpcs to finish, cancelling them at the deadline
+func gracefulStop(ctx context.Context, server *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		return errors.Wrap(ctx.Err(), "gRPC server did not drain, in flight rpcs cancelled")
 	}
 }
 
-func startWebAPI(logger zerolog.Logger, settings *config.Settings, userDeviceSvc services.UserDeviceAPIService,
-	drivlySvc services.DrivlyValuationService, vincarioSvc services.VincarioValuationService, identity gateways.IdentityAPI,
-	telemetry gateways.TelemetryAPI, locationSvc services.LocationService) *fiber.App {
+func startWebAPI(lc *Lifecycle, logger zerolog.Logger, settings *config.Settings, userDeviceSvc services.UserDeviceAPIService,
+	providers *services.ValuationProviderRegistry, identity gateways.IdentityAPI,
+	telemetry gateways.TelemetryAPI, locationSvc services.LocationService, jobs services.ValuationJobService,
+	portfolio services.PortfolioService) {
 
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -123,7 +211,8 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, userDeviceSvc
 	app.Get("/", healthCheck)
 	app.Get("/v1/swagger/*", swagger.HandlerDefault)
 
-	vehiclesController := controllers.NewVehiclesController(&logger, userDeviceSvc, drivlySvc, vincarioSvc, identity, telemetry, locationSvc)
+	vehiclesController := controllers.NewVehiclesController(&logger, userDeviceSvc, providers, identity, telemetry, locationSvc, jobs)
+	ownersController := controllers.NewOwnersController(&logger, portfolio)
 
 	// secured paths
 	privilegeAuth := jwtware.New(jwtware.Config{
@@ -139,21 +228,32 @@ func startWebAPI(logger zerolog.Logger, settings *config.Settings, userDeviceSvc
 
 	vOwner := app.Group("/v2/vehicles/:tokenId", privilegeAuth)
 	vOwner.Get("/valuations", tk.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), vehiclesController.GetValuations)
+	vOwner.Get("/valuations/history", tk.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), vehiclesController.GetValuationHistory)
 	vOwner.Get("/offers", tk.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), vehiclesController.GetOffers)
 	// request an offer of valuation
 	vOwner.Post("/instant-offer", tk.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData, privileges.VehicleVinCredential}), vehiclesController.RequestInstantOffer)
 	vOwner.Post("/valuation", tk.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData, privileges.VehicleVinCredential}), vehiclesController.RequestValuationOnly)
 	// same as above but it causes confusion so
 	vOwner.Post("/valuations", tk.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData, privileges.VehicleVinCredential}), vehiclesController.RequestValuationOnly)
+	vOwner.Get("/jobs/:jobId", tk.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData}), vehiclesController.GetJob)
+
+	// user jwt, the address must be the user's
+	userAuth := jwtware.New(jwtware.Config{
+		JWKSetURLs: []string{settings.JwtKeySetURL},
+		ErrorHandler: func(_ *fiber.Ctx, _ error) error {
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid user token.")
+		},
+	})
+	app.Get("/v2/owners/:address/valuations", userAuth, ownersController.GetOwnerValuations)
 
 	logger.Info().Msg("HTTP web server started on port " + settings.Port)
 	// Start Server from a different go routine
 	go func() {
 		if err := app.Listen(":" + settings.Port); err != nil {
-			logger.Fatal().Err(err).Send()
+			lc.Fail(errors.Wrapf(err, "failed to start HTTP web server on port %s", settings.Port))
 		}
 	}()
-	return app
+	lc.OnStop("HTTP web server", app.ShutdownWithContext)
 }
 
 type CodeResp struct {
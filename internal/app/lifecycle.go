@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultShutdownTimeout    = 20 * time.Second
+	defaultShutdownDrainDelay = 5 * time.Second
+)
+
+type stopper struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Lifecycle keeps what the API started so it can be stopped on SIGTERM. Shutdown first reports not ready, waits the
+// drain delay for Kubernetes to stop routing, then stops everything in reverse order of registration within the
+// shutdown timeout.
+type Lifecycle struct {
+	logger     zerolog.Logger
+	timeout    time.Duration
+	drainDelay time.Duration
+
+	ready    atomic.Bool
+	stoppers []stopper
+	// ctx of the workers started with Go, cancelled when they are stopped
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers sync.WaitGroup
+	// hasWorkers whether the workers stop is registered
+	hasWorkers bool
+	failed     chan error
+}
+
+// NewLifecycle parses the shutdown durations, eg. 20s, using the defaults when empty
+func NewLifecycle(ctx context.Context, logger zerolog.Logger, shutdownTimeout, drainDelay string) (*Lifecycle, error) {
+	l := &Lifecycle{
+		logger:     logger,
+		timeout:    defaultShutdownTimeout,
+		drainDelay: defaultShutdownDrainDelay,
+		failed:     make(chan error, 1),
+	}
+	var err error
+	if shutdownTimeout != "" {
+		if l.timeout, err = time.ParseDuration(shutdownTimeout); err != nil {
+			return nil, errors.Wrapf(err, "invalid SHUTDOWN_TIMEOUT %s", shutdownTimeout)
+		}
+	}
+	if drainDelay != "" {
+		if l.drainDelay, err = time.ParseDuration(drainDelay); err != nil {
+			return nil, errors.Wrapf(err, "invalid SHUTDOWN_DRAIN_DELAY %s", drainDelay)
+		}
+	}
+	l.ctx, l.cancel = context.WithCancel(ctx)
+	l.ready.Store(true)
+	return l, nil
+}
+
+// Ready false once shutting down
+func (l *Lifecycle) Ready() bool {
+	return l.ready.Load()
+}
+
+// OnStop registers a stop function, called with a context that expires at the shutdown deadline
+func (l *Lifecycle) OnStop(name string, stop func(ctx context.Context) error) {
+	l.stoppers = append(l.stoppers, stopper{name: name, stop: stop})
+}
+
+// Go runs the worker until workers are stopped, which cancels their context and waits for them to return. Workers are
+// stopped together, in the order the first was started
+func (l *Lifecycle) Go(name string, run func(ctx context.Context)) {
+	l.workers.Add(1)
+	go func() {
+		defer l.workers.Done()
+		run(l.ctx)
+		l.logger.Info().Msgf("%s stopped", name)
+	}()
+	if !l.hasWorkers {
+		l.hasWorkers = true
+		l.OnStop("workers", l.stopWorkers)
+	}
+}
+
+func (l *Lifecycle) stopWorkers(ctx context.Context) error {
+	l.cancel()
+	done := make(chan struct{})
+	go func() {
+		l.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.Wrap(ctx.Err(), "workers did not stop")
+	}
+}
+
+// Fail shuts down because something the API needs stopped, eg. a server could not listen on its port
+func (l *Lifecycle) Fail(err error) {
+	select {
+	case l.failed <- err:
+	default:
+	}
+}
+
+// Wait blocks until SIGINT, SIGTERM or Fail. Returns the error passed to Fail
+func (l *Lifecycle) Wait() error {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(c)
+	select {
+	case sig := <-c:
+		l.logger.Info().Msgf("received %s, shutting down", sig)
+		return nil
+	case err := <-l.failed:
+		return err
+	}
+}
+
+// Shutdown reports not ready, waits the drain delay, then stops everything registered in reverse order. Returns the
+// first stop error, everything is stopped regardless
+func (l *Lifecycle) Shutdown() error {
+	l.ready.Store(false)
+	if l.drainDelay > 0 {
+		l.logger.Info().Msgf("not ready, draining for %s", l.drainDelay)
+		time.Sleep(l.drainDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+	var firstErr error
+	for i := len(l.stoppers) - 1; i >= 0; i-- {
+		s := l.stoppers[i]
+		if err := s.stop(ctx); err != nil {
+			l.logger.Err(err).Msgf("failed to stop %s", s.name)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		l.logger.Info().Msgf("stopped %s", s.name)
+	}
+	l.cancel()
+	return firstErr
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycle_Shutdown(t *testing.T) {
+	lc, err := NewLifecycle(context.Background(), zerolog.Nop(), "1s", "0s")
+	require.NoError(t, err)
+
+	var stopped []string
+	var readyWhileStopping bool
+	lc.OnStop("monitoring", func(context.Context) error {
+		stopped = append(stopped, "monitoring")
+		return nil
+	})
+	lc.Go("worker", func(ctx context.Context) {
+		<-ctx.Done()
+		stopped = append(stopped, "worker")
+	})
+	lc.OnStop("http", func(context.Context) error {
+		readyWhileStopping = lc.Ready()
+		stopped = append(stopped, "http")
+		return errors.New("failed to drain")
+	})
+
+	assert.True(t, lc.Ready())
+	err = lc.Shutdown()
+
+	assert.EqualError(t, err, "failed to drain")
+	assert.False(t, lc.Ready())
+	assert.False(t, readyWhileStopping, "not ready before anything stops")
+	assert.Equal(t, []string{"http", "worker", "monitoring"}, stopped, "stopped in reverse order, all despite the error")
+}
+
+func TestLifecycle_Shutdown_timeout(t *testing.T) {
+	lc, err := NewLifecycle(context.Background(), zerolog.Nop(), "10ms", "0s")
+	require.NoError(t, err)
+	release := make(chan struct{})
+	defer close(release)
+	lc.Go("stuck worker", func(context.Context) { <-release })
+
+	err = lc.Shutdown()
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+}
+
+func TestLifecycle_Wait_fail(t *testing.T) {
+	lc, err := NewLifecycle(context.Background(), zerolog.Nop(), "", "")
+	require.NoError(t, err)
+	lc.Fail(errors.New("port in use"))
+	lc.Fail(errors.New("second failure"))
+
+	done := make(chan error)
+	go func() { done <- lc.Wait() }()
+	select {
+	case err := <-done:
+		assert.EqualError(t, err, "port in use")
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after Fail")
+	}
+}
+
+func TestNewLifecycle_invalidDuration(t *testing.T) {
+	_, err := NewLifecycle(context.Background(), zerolog.Nop(), "twenty", "")
+	assert.Error(t, err)
+}
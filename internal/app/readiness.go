@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pressly/goose/v3"
+)
+
+const (
+	// readinessCacheTTL how long check results are reused, so frequent probes don't hammer the dependencies
+	readinessCacheTTL = 10 * time.Second
+	// readinessCheckTimeout how long a single dependency check may take before it counts as down
+	readinessCheckTimeout = 3 * time.Second
+
+	// same as the migrate command, migrations are shipped next to the binary in the image
+	migrationsDir   = "internal/infrastructure/db/migrations"
+	migrationsTable = "valuations_api.migrations"
+)
+
+// DependencyCheck errors when the dependency is unreachable. Only critical dependencies make the API not ready, the
+// rest are reported so losing eg. identity-api doesn't take every pod out of the service at once.
+type DependencyCheck struct {
+	Name     string
+	Critical bool
+	Check    func(ctx context.Context) error
+}
+
+// DependencyStatus result of a dependency check
+type DependencyStatus struct {
+	Ready    bool   `json:"ready"`
+	Critical bool   `json:"critical"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ReadinessReport body of /ready
+type ReadinessReport struct {
+	Ready        bool                        `json:"ready"`
+	CheckedAt    time.Time                   `json:"checkedAt"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// readiness runs the dependency checks concurrently, caching the report for readinessCacheTTL
+type readiness struct {
+	checks []DependencyCheck
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	report *ReadinessReport
+}
+
+func newReadiness(checks ...DependencyCheck) *readiness {
+	return &readiness{checks: checks, ttl: readinessCacheTTL, now: time.Now}
+}
+
+// Report the cached report, checking again once it is older than the ttl. Concurrent callers wait for the same check
+func (r *readiness) Report(ctx context.Context) ReadinessReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.report != nil && r.now().Sub(r.report.CheckedAt) < r.ttl {
+		return *r.report
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+	statuses := make([]DependencyStatus, len(r.checks))
+	var wg sync.WaitGroup
+	for i, check := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = DependencyStatus{Ready: true, Critical: check.Critical}
+			if err := check.Check(ctx); err != nil {
+				statuses[i].Ready = false
+				statuses[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := &ReadinessReport{Ready: true, CheckedAt: r.now(), Dependencies: make(map[string]DependencyStatus, len(r.checks))}
+	for i, check := range r.checks {
+		report.Dependencies[check.Name] = statuses[i]
+		if check.Critical && !statuses[i].Ready {
+			report.Ready = false
+		}
+	}
+	r.report = report
+	return *report
+}
+
+// migrationsApplied errors until the database is at the version of the newest migration shipped with the API. Once
+// it passes it keeps passing, the startup probe is done by then.
+type migrationsApplied struct {
+	db  *sql.DB
+	dir string
+
+	mu       sync.Mutex
+	expected int64
+	applied  bool
+}
+
+func newMigrationsApplied(db *sql.DB) *migrationsApplied {
+	return &migrationsApplied{db: db, dir: migrationsDir}
+}
+
+func (m *migrationsApplied) Check(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.applied {
+		return nil
+	}
+	if m.expected == 0 {
+		migrations, err := goose.CollectMigrations(m.dir, 0, goose.MaxVersion)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read migrations from %s", m.dir)
+		}
+		last, err := migrations.Last()
+		if err != nil {
+			return errors.Wrapf(err, "no migrations in %s", m.dir)
+		}
+		m.expected = last.Version
+	}
+
+	goose.SetTableName(migrationsTable)
+	current, err := goose.GetDBVersionContext(ctx, m.db)
+	if err != nil {
+		return errors.Wrap(err, "failed to get database migration version")
+	}
+	if current < m.expected {
+		return errors.Errorf("database at migration %d, expecting %d", current, m.expected)
+	}
+	m.applied = true
+	return nil
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness_Report(t *testing.T) {
+	var dbCalls atomic.Int32
+	identityErr := errors.New("connection refused")
+	r := newReadiness(
+		DependencyCheck{Name: "postgres", Critical: true, Check: func(context.Context) error {
+			dbCalls.Add(1)
+			return nil
+		}},
+		DependencyCheck{Name: "identity-api", Check: func(context.Context) error { return identityErr }},
+	)
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	report := r.Report(context.Background())
+
+	assert.True(t, report.Ready, "non critical dependencies don't make the api not ready")
+	assert.Equal(t, DependencyStatus{Ready: true, Critical: true}, report.Dependencies["postgres"])
+	assert.Equal(t, DependencyStatus{Ready: false, Error: "connection refused"}, report.Dependencies["identity-api"])
+
+	now = now.Add(readinessCacheTTL / 2)
+	r.Report(context.Background())
+	assert.Equal(t, int32(1), dbCalls.Load(), "cached within the ttl")
+
+	now = now.Add(readinessCacheTTL)
+	r.Report(context.Background())
+	assert.Equal(t, int32(2), dbCalls.Load(), "checked again after the ttl")
+}
+
+func TestReadiness_Report_criticalDown(t *testing.T) {
+	r := newReadiness(DependencyCheck{Name: "postgres", Critical: true, Check: func(context.Context) error {
+		return errors.New("connection refused")
+	}})
+
+	report := r.Report(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.False(t, report.Dependencies["postgres"].Ready)
+}
+
+func TestMigrationsApplied_Check_noMigrations(t *testing.T) {
+	m := newMigrationsApplied(nil)
+	m.dir = t.TempDir()
+
+	err := m.Check(context.Background())
+
+	assert.ErrorContains(t, err, "failed to read migrations")
+}
@@ -39,4 +39,36 @@ var (
 		},
 		[]string{"method", "path", "status"},
 	)
+
+	VendorRequestCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "valuations_api_vendor_request_count",
+			Help: "The total number of calls to vendor apis, by the outcome after retries",
+		},
+		[]string{"vendor", "outcome"},
+	)
+
+	VendorRetryCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "valuations_api_vendor_retry_count",
+			Help: "The total number of retried vendor api calls",
+		},
+		[]string{"vendor"},
+	)
+
+	VendorCircuitOpenCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "valuations_api_vendor_circuit_open_count",
+			Help: "The total number of times a vendor circuit breaker tripped open",
+		},
+		[]string{"vendor"},
+	)
+
+	IdentityCacheCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "valuations_api_identity_cache_count",
+			Help: "The total number of cached identity-api lookups, by whether they were served from the cache",
+		},
+		[]string{"kind", "result"},
+	)
 )
@@ -11,6 +11,7 @@ type Settings struct {
 	LogLevel                  string      `yaml:"LOG_LEVEL"`
 	Port                      string      `yaml:"PORT"`
 	GRPCPort                  string      `yaml:"GRPC_PORT"`
+	GRPCServiceJWTSecret      string      `yaml:"GRPC_SERVICE_JWT_SECRET"`
 	MonitoringPort            string      `yaml:"MONITORING_PORT"`
 	DB                        db.Settings `yaml:"DB"`
 	JwtKeySetURL              string      `yaml:"JWT_KEY_SET_URL"`
@@ -34,12 +35,47 @@ type Settings struct {
 	NATSValuationDurableConsumer string `yaml:"NATS_VALUATION_DURABLE_CONSUMER"`
 	NATSOfferSubject             string `yaml:"NATS_OFFER_SUBJECT"`
 	NATSOfferDurableConsumer     string `yaml:"NATS_OFFER_DURABLE_CONSUMER"`
+	NATSJobsBucket               string `yaml:"NATS_JOBS_BUCKET"`
 	UsersGRPCAddr                string `yaml:"USERS_GRPC_ADDR"`
 	VehicleNFTAddress            string `yaml:"VEHICLE_NFT_ADDRESS"`
 	TokenExchangeJWTKeySetURL    string `yaml:"TOKEN_EXCHANGE_JWT_KEY_SET_URL"`
 
 	IdentityAPIURL  url.URL `yaml:"IDENTITY_API_URL"`
 	TelemetryAPIURL url.URL `yaml:"TELEMETRY_API_URL"`
+
+	// identity-api vehicle, definition and manufacturer lookups are cached for the ttl, not found ones for the negative
+	// ttl, eg. 5m. Defaults 5m, 1m and 10000 entries of each kind
+	IdentityCacheTTL         string `yaml:"IDENTITY_CACHE_TTL"`
+	IdentityCacheNegativeTTL string `yaml:"IDENTITY_CACHE_NEGATIVE_TTL"`
+	IdentityCacheSize        int    `yaml:"IDENTITY_CACHE_SIZE"`
+
+	// country to provider routing, eg. USA,CAN:drivly;*:vincario. When empty each provider's supported countries are used
+	ValuationProviderRouting string `yaml:"VALUATION_PROVIDER_ROUTING"`
+	OfferProviderRouting     string `yaml:"OFFER_PROVIDER_ROUTING"`
+
+	// country to price blending strategy for the user display price, eg. USA:weighted;*:vendor. One of vendor, mean,
+	// median, weighted, trimmed or stdev. Countries without a route use vendor, the price the vendor gives
+	PriceBlendingRouting string `yaml:"PRICE_BLENDING_ROUTING"`
+	// source weights for the weighted strategy, eg. blackbook:2,kbb:1. Sources not listed weigh 1
+	PriceBlendingWeights string `yaml:"PRICE_BLENDING_WEIGHTS"`
+	// percent of prices dropped from each end by the trimmed strategy, default 10
+	PriceBlendingTrimPercent float64 `yaml:"PRICE_BLENDING_TRIM_PERCENT"`
+	// prices more than this many standard deviations from the mean are rejected by the stdev strategy, default 2
+	PriceBlendingStdevLimit float64 `yaml:"PRICE_BLENDING_STDEV_LIMIT"`
+
+	// EV battery state of health to price adjustment, as percent:percent points, eg. 100:0,90:-4,80:-12. Interpolated
+	// between points and flat past the ends. Default curve when empty
+	EVBatteryAdjustmentCurve string `yaml:"EV_BATTERY_ADJUSTMENT_CURVE"`
+
+	// how often to re-pull valuations past their provider repull window, eg. 24h. Disabled when empty. Revaluations have
+	// no privilege token for telemetry, their mileage is estimated
+	RevaluationInterval    string `yaml:"REVALUATION_INTERVAL"`
+	RevaluationConcurrency int    `yaml:"REVALUATION_CONCURRENCY"`
+
+	// on SIGTERM /health reports not ready for the drain delay before servers stop, then servers and workers get the
+	// timeout to finish in flight work. Durations, eg. 5s, default 20s timeout and 5s drain delay
+	ShutdownTimeout    string `yaml:"SHUTDOWN_TIMEOUT"`
+	ShutdownDrainDelay string `yaml:"SHUTDOWN_DRAIN_DELAY"`
 }
 
 func (s *Settings) IsProduction() bool {
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -11,3 +12,20 @@ func GetUserID(c *fiber.Ctx) string {
 	userID := claims["sub"].(string)
 	return userID
 }
+
+// GetUserEthAddress ethereum address claim of the user jwt, false if the user has none
+func GetUserEthAddress(c *fiber.Ctx) (common.Address, bool) {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return common.Address{}, false
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return common.Address{}, false
+	}
+	addr, ok := claims["ethereum_address"].(string)
+	if !ok || !common.IsHexAddress(addr) {
+		return common.Address{}, false
+	}
+	return common.HexToAddress(addr), true
+}
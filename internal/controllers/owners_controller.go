@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/DIMO-Network/valuations-api/internal/controllers/helpers"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// the swagger annotations refer to core types the handlers don't otherwise use
+var _ core.OwnerPortfolio
+
+type OwnersController struct {
+	log       *zerolog.Logger
+	portfolio services.PortfolioService
+}
+
+func NewOwnersController(log *zerolog.Logger, portfolio services.PortfolioService) *OwnersController {
+	return &OwnersController{
+		log:       log,
+		portfolio: portfolio,
+	}
+}
+
+// GetOwnerValuations godoc
+// @Description gets the latest valuation of every vehicle owned by the address, with the total value of the portfolio.
+// @Description Vehicles never valued get an estimate from the valued ones, included in estimatedTotalValue.
+// @Tags        valuations
+// @Produce     json
+// @Param 		address path string true "owner ethereum address, must be the address of the authenticated user"
+// @Param 		currency query string false "ISO 4217 currency to convert values to, default USD"
+// @Success     200 {object} core.OwnerPortfolio
+// @Security    BearerAuth
+// @Router      /v2/owners/{address}/valuations [get]
+func (oc *OwnersController) GetOwnerValuations(c *fiber.Ctx) error {
+	addrStr := c.Params("address")
+	if !common.IsHexAddress(addrStr) {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse owner address.")
+	}
+	owner := common.HexToAddress(addrStr)
+	userAddr, ok := helpers.GetUserEthAddress(c)
+	if !ok || userAddr != owner {
+		return fiber.NewError(fiber.StatusForbidden, "Can only get valuations for your own address.")
+	}
+	currency := strings.ToUpper(c.Query("currency"))
+	if currency != "" && !currencyRegex.MatchString(currency) {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid currency, expected a 3 letter ISO 4217 code.")
+	}
+
+	portfolio, err := oc.portfolio.GetOwnerPortfolio(c.Context(), owner.Hex(), currency)
+	if err != nil {
+		if errors.Is(err, services.ErrNoFxRate) {
+			return fiber.NewError(fiber.StatusBadRequest, "No exchange rate available for currency "+currency)
+		}
+		return err
+	}
+
+	return c.JSON(portfolio)
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
+	mock_services "github.com/DIMO-Network/valuations-api/internal/core/services/mocks"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/dbtest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const ownerAddress = "0x1ABC7154748D1CE5144478CDEB574AE244B939B5"
+
+func ethAddressInjector(addr string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("user", jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":              userID,
+			"ethereum_address": addr,
+		}))
+		return c.Next()
+	}
+}
+
+func TestOwnersController_GetOwnerValuations(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	portfolio := mock_services.NewMockPortfolioService(mockCtrl)
+	controller := NewOwnersController(dbtest.Logger(), portfolio)
+	app := dbtest.SetupAppFiber(*dbtest.Logger())
+	app.Get("/owners/:address/valuations", ethAddressInjector(ownerAddress), controller.GetOwnerValuations)
+
+	portfolio.EXPECT().GetOwnerPortfolio(gomock.Any(), common.HexToAddress(ownerAddress).Hex(), "EUR").
+		Return(&core.OwnerPortfolio{Currency: "EUR", TotalValue: 41000, ValuedCount: 2, UnvaluedCount: 1}, nil)
+
+	// address case doesn't matter
+	response, err := app.Test(dbtest.BuildRequest("GET", "/owners/0x1abc7154748d1ce5144478cdeb574ae244b939b5/valuations?currency=eur", ""))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, response.StatusCode)
+	body, _ := io.ReadAll(response.Body)
+	result := core.OwnerPortfolio{}
+	require.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, 41000, result.TotalValue)
+	assert.Equal(t, 1, result.UnvaluedCount)
+
+	portfolio.EXPECT().GetOwnerPortfolio(gomock.Any(), gomock.Any(), "XYZ").
+		Return(nil, errors.Wrap(services.ErrNoFxRate, "XYZ"))
+	response, err = app.Test(dbtest.BuildRequest("GET", fmt.Sprintf("/owners/%s/valuations?currency=XYZ", ownerAddress), ""))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+}
+
+func TestOwnersController_GetOwnerValuations_NotOwner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	controller := NewOwnersController(dbtest.Logger(), mock_services.NewMockPortfolioService(mockCtrl))
+	app := dbtest.SetupAppFiber(*dbtest.Logger())
+	app.Get("/owners/:address/valuations", ethAddressInjector(ownerAddress), controller.GetOwnerValuations)
+
+	response, err := app.Test(dbtest.BuildRequest("GET", "/owners/0x0000000000000000000000000000000000000001/valuations", ""))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, response.StatusCode)
+
+	response, err = app.Test(dbtest.BuildRequest("GET", "/owners/not-an-address/valuations", ""))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, response.StatusCode)
+}
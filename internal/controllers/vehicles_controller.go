@@ -2,7 +2,9 @@ package controllers
 
 import (
 	"math/big"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/DIMO-Network/shared/pkg/logfields"
 	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
@@ -11,32 +13,36 @@ import (
 	core "github.com/DIMO-Network/valuations-api/internal/core/models"
 
 	"github.com/DIMO-Network/valuations-api/internal/core/services"
+	"github.com/DIMO-Network/valuations-api/internal/core/units"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
 )
 
+// currencyRegex ISO 4217 alphabetic code
+var currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
 type VehiclesController struct {
-	log                  *zerolog.Logger
-	userDeviceService    services.UserDeviceAPIService
-	drivlyValuationSvc   services.DrivlyValuationService
-	vincarioValuationSvc services.VincarioValuationService
-	identityAPI          gateways.IdentityAPI
-	telemetryAPI         gateways.TelemetryAPI
-	locationSvc          services.LocationService
+	log               *zerolog.Logger
+	userDeviceService services.UserDeviceAPIService
+	providers         *services.ValuationProviderRegistry
+	identityAPI       gateways.IdentityAPI
+	telemetryAPI      gateways.TelemetryAPI
+	locationSvc       services.LocationService
+	jobs              services.ValuationJobService
 }
 
 func NewVehiclesController(log *zerolog.Logger,
-	userDeviceSvc services.UserDeviceAPIService, drivlyValuationSvc services.DrivlyValuationService,
-	vincarioValuationSvc services.VincarioValuationService, identityAPI gateways.IdentityAPI,
-	telemetryAPI gateways.TelemetryAPI, locationSvc services.LocationService) *VehiclesController {
+	userDeviceSvc services.UserDeviceAPIService, providers *services.ValuationProviderRegistry,
+	identityAPI gateways.IdentityAPI, telemetryAPI gateways.TelemetryAPI, locationSvc services.LocationService,
+	jobs services.ValuationJobService) *VehiclesController {
 	return &VehiclesController{
-		log:                  log,
-		userDeviceService:    userDeviceSvc,
-		drivlyValuationSvc:   drivlyValuationSvc,
-		vincarioValuationSvc: vincarioValuationSvc,
-		identityAPI:          identityAPI,
-		telemetryAPI:         telemetryAPI,
-		locationSvc:          locationSvc,
+		log:               log,
+		userDeviceService: userDeviceSvc,
+		providers:         providers,
+		identityAPI:       identityAPI,
+		telemetryAPI:      telemetryAPI,
+		locationSvc:       locationSvc,
+		jobs:              jobs,
 	}
 }
 
@@ -45,6 +51,8 @@ func NewVehiclesController(log *zerolog.Logger,
 // @Tags        valuations
 // @Produce     json
 // @Param 		tokenId path string true "tokenId for vehicle to get offers"
+// @Param 		currency query string false "ISO 4217 currency to convert prices to, eg. USD. Defaults to the vendor currency"
+// @Param 		units query string false "km or mi to convert mileage and odometer to. Defaults to the vendor unit"
 // @Success     200 {object} core.DeviceValuation
 // @Security    BearerAuth
 // @Router      /v2/vehicles/{tokenId}/valuations [get]
@@ -54,7 +62,16 @@ func (vc *VehiclesController) GetValuations(c *fiber.Ctx) error {
 	if !ok {
 		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse token id.")
 	}
-	_, err := vc.identityAPI.GetVehicle(tokenID.Uint64())
+	currency := strings.ToUpper(c.Query("currency"))
+	if currency != "" && !currencyRegex.MatchString(currency) {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid currency, expected a 3 letter ISO 4217 code.")
+	}
+	unit, err := distanceUnitQuery(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = vc.identityAPI.GetVehicle(c.Context(), tokenID.Uint64())
 	if err != nil {
 		return err
 	}
@@ -67,19 +84,94 @@ func (vc *VehiclesController) GetValuations(c *fiber.Ctx) error {
 	//	take = 10
 	//}
 	// need to pass in userDeviceId until totally complete migration
-	valuation, err := vc.userDeviceService.GetValuations(c.Context(), tokenID.Uint64(), privJWT)
+	valuation, err := vc.userDeviceService.GetValuations(c.Context(), tokenID.Uint64(), privJWT, currency)
 	if err != nil {
+		if errors.Is(err, services.ErrNoFxRate) {
+			return fiber.NewError(fiber.StatusBadRequest, "No exchange rate available for currency "+currency)
+		}
 		return err
 	}
+	if unit != "" {
+		for i := range valuation.ValuationSets {
+			valuation.ValuationSets[i].ConvertDistances(unit)
+		}
+	}
 
 	return c.JSON(valuation)
 }
 
+// GetValuationHistory godoc
+// @Description gets every valuation pulled for a particular vehicle over time, newest first, so the value can be charted.
+// @Description Paginated, pass the nextCursor from the response as the cursor param to get the next page.
+// @Tags        valuations
+// @Produce     json
+// @Param 		tokenId path string true "tokenId for vehicle to get valuations"
+// @Param 		from query string false "only valuations created at or after this time, RFC3339"
+// @Param 		to query string false "only valuations created before this time, RFC3339"
+// @Param 		cursor query string false "nextCursor from the previous page"
+// @Param 		limit query int false "page size, default 50, max 200"
+// @Param 		units query string false "km or mi to convert mileage and odometer to. Defaults to the vendor unit"
+// @Success     200 {object} core.ValuationHistory
+// @Security    BearerAuth
+// @Router      /v2/vehicles/{tokenId}/valuations/history [get]
+func (vc *VehiclesController) GetValuationHistory(c *fiber.Ctx) error {
+	tidStr := c.Params("tokenId")
+	tokenID, ok := new(big.Int).SetString(tidStr, 10)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse token id.")
+	}
+	query := core.ValuationHistoryQuery{
+		Cursor: c.Query("cursor"),
+		Limit:  c.QueryInt("limit", services.DefaultValuationHistoryLimit),
+	}
+	var err error
+	if from := c.Query("from"); from != "" {
+		if query.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse from, expected RFC3339.")
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if query.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse to, expected RFC3339.")
+		}
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return fiber.NewError(fiber.StatusBadRequest, "from must be before to.")
+	}
+	unit, err := distanceUnitQuery(c)
+	if err != nil {
+		return err
+	}
+	_, err = vc.identityAPI.GetVehicle(c.Context(), tokenID.Uint64())
+	if err != nil {
+		return err
+	}
+
+	privJWT := c.Get(fiber.HeaderAuthorization)
+
+	history, err := vc.userDeviceService.GetValuationHistory(c.Context(), tokenID.Uint64(), privJWT, query)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCursor) {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid cursor")
+		}
+		return err
+	}
+	if unit != "" {
+		for i := range history.ValuationSets {
+			history.ValuationSets[i].ConvertDistances(unit)
+		}
+	}
+
+	return c.JSON(history)
+}
+
 // GetOffers godoc
-// @Description gets any existing offers for a particular user device. You must call instant-offer endpoint first to pull newer. Returns list.
+// @Description gets all existing offers for a particular user device, newest first. You must call instant-offer endpoint first to pull newer.
+// @Description Offers expire after 7 days, check expired and request a new instant offer to get working offer links.
 // @Tags        offers
 // @Produce     json
 // @Param 		tokenId path string true "tokenId for vehicle to get offers"
+// @Param 		units query string false "km or mi to convert mileage to. Defaults to mi"
 // @Success     200 {object} core.DeviceOffer
 // @Security    BearerAuth
 // @Router      /v2/vehicles/{tokenId}/offers [get]
@@ -89,32 +181,35 @@ func (vc *VehiclesController) GetOffers(c *fiber.Ctx) error {
 	if !ok {
 		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse token id.")
 	}
-	_, err := vc.identityAPI.GetVehicle(tokenID.Uint64())
+	unit, err := distanceUnitQuery(c)
+	if err != nil {
+		return err
+	}
+	_, err = vc.identityAPI.GetVehicle(c.Context(), tokenID.Uint64())
 	if err != nil {
 		return err
 	}
 
-	//takeStr := c.Query("take")
-	//take, err := strconv.Atoi(takeStr)
-	//if err != nil || take <= 0 {
-	//	take = 10
-	//}
-	// todo change below to get list. Make sure that if older than 7 days does not include offer link
 	offer, err := vc.userDeviceService.GetOffers(c.Context(), tokenID.Uint64())
 	if err != nil {
 		return err
 	}
+	if unit != "" {
+		for i := range offer.OfferSets {
+			offer.OfferSets[i].ConvertDistances(unit)
+		}
+	}
 
 	return c.JSON(offer)
 }
 
 // RequestInstantOffer godoc
-// @Description makes a request for an instant offer for a particular user device. Simply returns success if able to create job.
-// @Description You will need to query the offers endpoint to see if a new offer showed up. Job can take about a minute to complete.
+// @Description makes a request for an instant offer for a particular user device. Queues a job and returns its id, poll the
+// @Description jobs endpoint to know when it completes, then query the offers endpoint. Job can take about a minute to complete.
 // @Tags        offers
 // @Produce     json
 // @Param 		tokenId path string true "tokenId for vehicle to get offers"
-// @Success     200
+// @Success     200 {object} controllers.JobQueuedResponse
 // @Security    BearerAuth
 // @Router      /v2/vehicles/{tokenId}/instant-offer [post]
 func (vc *VehiclesController) RequestInstantOffer(c *fiber.Ctx) error {
@@ -146,10 +241,7 @@ func (vc *VehiclesController) RequestInstantOffer(c *fiber.Ctx) error {
 	if didGetErrorLastTime {
 		return fiber.NewError(fiber.StatusBadRequest, "no offers found for you vehicle in last request")
 	}
-	var valuationErr error
-	var status core.DataPullStatusEnum
-
-	signals, err := vc.telemetryAPI.GetLatestSignals(tokenID.Uint64(), privJWT)
+	signals, err := vc.telemetryAPI.GetLatestSignals(c.Context(), tokenID.Uint64(), privJWT)
 	if err != nil {
 		return errors.Wrap(err, "failed to get latest signals for tokenId: "+tidStr)
 	}
@@ -157,34 +249,33 @@ func (vc *VehiclesController) RequestInstantOffer(c *fiber.Ctx) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to get geo decoded location for tokenId: "+tidStr)
 	}
-	vinVC, err := vc.telemetryAPI.GetVinVC(tokenID.Uint64(), privJWT)
+	if len(vc.providers.OfferProvidersForCountry(location.CountryCode)) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "instant offers not available in country: "+location.CountryCode)
+	}
+	vinVC, err := vc.telemetryAPI.GetVinVC(c.Context(), tokenID.Uint64(), privJWT)
 	if err != nil {
 		return errors.Wrap(err, "failed to get vinVC for tokenId: "+tidStr)
 	}
-	countryThreeLetter := services.ConvertSupportedCountry(location.CountryCode)
-
-	if strings.Contains(services.NorthAmercanCountries, countryThreeLetter) {
-		status, valuationErr = vc.drivlyValuationSvc.PullOffer(c.Context(), tokenID.Uint64(), vinVC.Vin, privJWT)
-	} else {
-		return fiber.NewError(fiber.StatusBadRequest, "unsupported country: "+location.CountryCode)
-		//status, valuationErr = vc.vincarioValuationSvc.PullValuation(c.Context(), tokenID.Uint64(), vinVC.Vin)
-	}
-	if valuationErr != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, valuationErr.Error())
+	job, err := vc.jobs.Enqueue(c.Context(), core.OfferJobType, tokenID.Uint64(), vinVC.Vin, location.CountryCode, privJWT, signals)
+	if err != nil {
+		localLog.Err(err).Msg("failed to queue offer job")
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
-	localLog.Info().Msgf("succesfully requested offer with status %s", status)
+	localLog.Info().Str("job_id", job.ID).Msg("queued offer job")
 
-	return c.JSON(fiber.Map{
-		"message": "instant offer request completed: " + status,
+	return c.JSON(JobQueuedResponse{
+		Message: "instant offer request queued",
+		JobID:   job.ID,
 	})
 }
 
 // RequestValuationOnly godoc
-// @Description request valuation only from drivly. Currently USA Only
+// @Description request valuation only, no offers. The valuation provider is chosen by the country the vehicle is located in.
+// @Description Queues a job and returns its id, poll the jobs endpoint to know when it completes.
 // @Tags        valuations
 // @Produce     json
-// @Param 		tokenId path string true "tokenId for USA based vehicle to get valuation"
-// @Success     200
+// @Param 		tokenId path string true "tokenId for vehicle to get valuation"
+// @Success     200 {object} controllers.JobQueuedResponse
 // @Security    BearerAuth
 // @Router      /v2/vehicles/{tokenId}/valuation [post]
 func (vc *VehiclesController) RequestValuationOnly(c *fiber.Ctx) error {
@@ -198,25 +289,86 @@ func (vc *VehiclesController) RequestValuationOnly(c *fiber.Ctx) error {
 
 	localLog := vc.log.With().Str(logfields.VehicleTokenID, tidStr).Str(logfields.HTTPPath, c.Path()).Logger()
 
-	var valuationErr error
-	var status core.DataPullStatusEnum
-
-	vinVC, err := vc.telemetryAPI.GetVinVC(tokenID.Uint64(), privJWT)
+	vinVC, err := vc.telemetryAPI.GetVinVC(c.Context(), tokenID.Uint64(), privJWT)
 	if err != nil {
 		return errors.Wrap(err, "failed to get vinVC for tokenId: "+tidStr)
 	}
 	if vinVC == nil {
 		return fiber.NewError(fiber.StatusBadRequest, "no vinVC found for tokenId: "+tidStr)
 	}
+	signals, err := vc.telemetryAPI.GetLatestSignals(c.Context(), tokenID.Uint64(), privJWT)
+	if err != nil {
+		localLog.Warn().Err(err).Msg("could not get latest signals, continuing with stored location")
+	}
+	location, err := vc.locationSvc.GetGeoDecodedLocation(c.Context(), signals, tokenID.Uint64())
+	if err != nil {
+		return errors.Wrap(err, "failed to get geo decoded location for tokenId: "+tidStr)
+	}
 
-	status, valuationErr = vc.drivlyValuationSvc.PullValuation(c.Context(), tokenID.Uint64(), vinVC.Vin, privJWT)
-	if valuationErr != nil {
-		localLog.Err(valuationErr).Msg("failed to get valuation from drivly")
-		return fiber.NewError(fiber.StatusInternalServerError, valuationErr.Error())
+	if len(vc.providers.ValuationProvidersForCountry(location.CountryCode)) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "unsupported country: "+location.CountryCode)
 	}
-	localLog.Info().Msgf("succesfully requested valuation with status %s", status)
+	job, err := vc.jobs.Enqueue(c.Context(), core.ValuationJobType, tokenID.Uint64(), vinVC.Vin, location.CountryCode, privJWT, signals)
+	if err != nil {
+		localLog.Err(err).Msg("failed to queue valuation job")
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	localLog.Info().Str("job_id", job.ID).Msg("queued valuation job")
 
-	return c.JSON(fiber.Map{
-		"message": "valuation request completed: " + status,
+	return c.JSON(JobQueuedResponse{
+		Message: "valuation request queued",
+		JobID:   job.ID,
 	})
 }
+
+// GetJob godoc
+// @Description gets the status of a valuation or instant offer job. Status is Queued or Processing until the job completes,
+// @Description then the pull result eg. PulledValuations or Skipped, or Error.
+// @Tags        valuations
+// @Produce     json
+// @Param 		tokenId path string true "tokenId for vehicle the job was requested for"
+// @Param 		jobId path string true "job id returned when requesting the valuation or instant offer"
+// @Success     200 {object} core.ValuationJob
+// @Security    BearerAuth
+// @Router      /v2/vehicles/{tokenId}/jobs/{jobId} [get]
+func (vc *VehiclesController) GetJob(c *fiber.Ctx) error {
+	tidStr := c.Params("tokenId")
+	tokenID, ok := new(big.Int).SetString(tidStr, 10)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse token id.")
+	}
+
+	job, err := vc.jobs.GetJob(c.Context(), c.Params("jobId"))
+	if err != nil {
+		if errors.Is(err, services.ErrJobNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "job not found")
+		}
+		return err
+	}
+	// privilege token is for this vehicle only
+	if job.TokenID != tokenID.Uint64() {
+		return fiber.NewError(fiber.StatusNotFound, "job not found")
+	}
+
+	return c.JSON(job)
+}
+
+// JobQueuedResponse is returned when a valuation or instant offer job is queued
+type JobQueuedResponse struct {
+	Message string `json:"message"`
+	// poll the jobs endpoint with this id for the job status
+	JobID string `json:"jobId"`
+}
+
+// distanceUnitQuery the units query param, empty when not set
+func distanceUnitQuery(c *fiber.Ctx) (units.DistanceUnit, error) {
+	query := c.Query("units")
+	if query == "" {
+		return "", nil
+	}
+	unit, err := units.ParseDistanceUnit(query)
+	if err != nil {
+		return "", fiber.NewError(fiber.StatusBadRequest, "Invalid units, expected km or mi.")
+	}
+	return unit, nil
+}
@@ -3,8 +3,12 @@ package controllers
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	mock_gateways "github.com/DIMO-Network/valuations-api/internal/core/gateways/mocks"
 
@@ -13,9 +17,11 @@ import (
 	"go.uber.org/mock/gomock"
 
 	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/core/services"
 	mock_services "github.com/DIMO-Network/valuations-api/internal/core/services/mocks"
 	"github.com/DIMO-Network/valuations-api/internal/infrastructure/dbtest"
 	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -36,6 +42,7 @@ type VehiclesControllerTestSuite struct {
 	identity             *mock_gateways.MockIdentityAPI
 	telemetry            *mock_gateways.MockTelemetryAPI
 	locationSvc          *mock_services.MockLocationService
+	jobs                 *mock_services.MockValuationJobService
 }
 
 // SetupSuite starts container db
@@ -50,12 +57,22 @@ func (s *VehiclesControllerTestSuite) SetupSuite() {
 	s.identity = mock_gateways.NewMockIdentityAPI(mockCtrl)
 	s.telemetry = mock_gateways.NewMockTelemetryAPI(mockCtrl)
 	s.locationSvc = mock_services.NewMockLocationService(mockCtrl)
+	s.jobs = mock_services.NewMockValuationJobService(mockCtrl)
+	s.drivlyValuationSvc.EXPECT().Name().Return(services.DrivlyProvider).AnyTimes()
+	s.vincarioValuationSvc.EXPECT().Name().Return(services.VincarioProvider).AnyTimes()
+	s.drivlyValuationSvc.EXPECT().SupportedCountries().Return([]string{"USA"}).AnyTimes()
+	s.drivlyValuationSvc.EXPECT().OfferCountries().Return(strings.Split(services.NorthAmercanCountries, ",")).AnyTimes()
+	s.vincarioValuationSvc.EXPECT().SupportedCountries().Return(strings.Split(services.EuropeanCountries, ",")).AnyTimes()
+	providers := services.NewValuationProviderRegistry(s.drivlyValuationSvc, s.vincarioValuationSvc)
 
-	controller := NewVehiclesController(logger, s.userDeviceSvc, s.drivlyValuationSvc, s.vincarioValuationSvc, s.identity, s.telemetry, s.locationSvc)
+	controller := NewVehiclesController(logger, s.userDeviceSvc, providers, s.identity, s.telemetry, s.locationSvc, s.jobs)
 	app := dbtest.SetupAppFiber(*logger)
 	app.Get("/vehicles/:tokenID/offers", dbtest.AuthInjectorTestHandler(userID), controller.GetOffers)
 	app.Get("/vehicles/:tokenID/valuations", dbtest.AuthInjectorTestHandler(userID), controller.GetValuations)
+	app.Get("/vehicles/:tokenID/valuations/history", dbtest.AuthInjectorTestHandler(userID), controller.GetValuationHistory)
 	app.Post("/vehicles/:tokenID/valuations", dbtest.AuthInjectorTestHandler(userID), controller.RequestValuationOnly)
+	app.Post("/vehicles/:tokenID/instant-offer", dbtest.AuthInjectorTestHandler(userID), controller.RequestInstantOffer)
+	app.Get("/vehicles/:tokenID/jobs/:jobId", dbtest.AuthInjectorTestHandler(userID), controller.GetJob)
 	s.controller = controller
 
 	s.app = app
@@ -84,12 +101,40 @@ func (s *VehiclesControllerTestSuite) TestPostRequestValuationOnly_Drivly1() {
 	tokenID := uint64(12345)
 	vin := "vinny"
 
-	s.telemetry.EXPECT().GetVinVC(tokenID, gomock.Any()).Return(&core.VinVCLatest{
+	s.telemetry.EXPECT().GetVinVC(gomock.Any(), tokenID, gomock.Any()).Return(&core.VinVCLatest{
 		Vin:         vin,
 		CountryCode: "USA",
 	}, nil)
-	s.drivlyValuationSvc.EXPECT().PullValuation(gomock.Any(), tokenID, vin, "").
-		Return(core.PulledValuationDrivlyStatus, nil)
+	signals := &core.SignalsLatest{}
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, gomock.Any()).Return(signals, nil)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), gomock.Any(), tokenID).Return(&core.LocationResponse{
+		CountryCode: "US",
+	}, nil)
+	// queued with the signals already fetched, not fetched again
+	s.jobs.EXPECT().Enqueue(gomock.Any(), core.ValuationJobType, tokenID, vin, "US", "", signals).
+		Return(&core.ValuationJob{ID: "job1", Type: core.ValuationJobType, TokenID: tokenID, Status: core.QueuedDataPullStatus}, nil)
+
+	request := dbtest.BuildRequest("POST", fmt.Sprintf("/vehicles/%d/valuations", tokenID), "")
+	response, _ := s.app.Test(request)
+	body, _ := io.ReadAll(response.Body)
+
+	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
+	resp := JobQueuedResponse{}
+	require.NoError(s.T(), json.Unmarshal(body, &resp))
+	assert.Equal(s.T(), "job1", resp.JobID)
+}
+
+func (s *VehiclesControllerTestSuite) TestPostRequestValuationOnly_RoutesToVincario() {
+	tokenID := uint64(12346)
+	vin := "WVWZZZ3CZWE123456"
+
+	s.telemetry.EXPECT().GetVinVC(gomock.Any(), tokenID, gomock.Any()).Return(&core.VinVCLatest{Vin: vin}, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, gomock.Any()).Return(&core.SignalsLatest{}, nil)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), gomock.Any(), tokenID).Return(&core.LocationResponse{
+		CountryCode: "DE",
+	}, nil)
+	s.jobs.EXPECT().Enqueue(gomock.Any(), core.ValuationJobType, tokenID, vin, "DE", "", gomock.Any()).
+		Return(&core.ValuationJob{ID: "job2", Type: core.ValuationJobType, TokenID: tokenID, Status: core.QueuedDataPullStatus}, nil)
 
 	request := dbtest.BuildRequest("POST", fmt.Sprintf("/vehicles/%d/valuations", tokenID), "")
 	response, _ := s.app.Test(request)
@@ -97,10 +142,106 @@ func (s *VehiclesControllerTestSuite) TestPostRequestValuationOnly_Drivly1() {
 	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
 }
 
+func (s *VehiclesControllerTestSuite) TestPostRequestValuationOnly_UnsupportedCountry() {
+	tokenID := uint64(12347)
+	vin := "9BWZZZ377VT004251"
+
+	s.telemetry.EXPECT().GetVinVC(gomock.Any(), tokenID, gomock.Any()).Return(&core.VinVCLatest{Vin: vin}, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, gomock.Any()).Return(&core.SignalsLatest{}, nil)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), gomock.Any(), tokenID).Return(&core.LocationResponse{
+		CountryCode: "BR",
+	}, nil)
+
+	request := dbtest.BuildRequest("POST", fmt.Sprintf("/vehicles/%d/valuations", tokenID), "")
+	response, _ := s.app.Test(request)
+
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+}
+
+func (s *VehiclesControllerTestSuite) TestPostRequestInstantOffer_NotAvailableInCountry() {
+	tokenID := uint64(12348)
+
+	s.userDeviceSvc.EXPECT().CanRequestInstantOffer(gomock.Any(), tokenID).Return(true, nil)
+	s.userDeviceSvc.EXPECT().LastRequestDidGiveError(gomock.Any(), tokenID).Return(false, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, gomock.Any()).Return(&core.SignalsLatest{}, nil)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), gomock.Any(), tokenID).Return(&core.LocationResponse{
+		CountryCode: "FR",
+	}, nil)
+
+	request := dbtest.BuildRequest("POST", fmt.Sprintf("/vehicles/%d/instant-offer", tokenID), "")
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	body, _ := io.ReadAll(response.Body)
+
+	// no valuation job queued in its place
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+	assert.Contains(s.T(), string(body), "instant offers not available")
+}
+
+func (s *VehiclesControllerTestSuite) TestPostRequestInstantOffer_QueuesOffer() {
+	tokenID := uint64(12349)
+	vin := "1FMCU9J94NUA12345"
+
+	s.userDeviceSvc.EXPECT().CanRequestInstantOffer(gomock.Any(), tokenID).Return(true, nil)
+	s.userDeviceSvc.EXPECT().LastRequestDidGiveError(gomock.Any(), tokenID).Return(false, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, gomock.Any()).Return(&core.SignalsLatest{}, nil)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), gomock.Any(), tokenID).Return(&core.LocationResponse{
+		CountryCode: "CA",
+	}, nil)
+	s.telemetry.EXPECT().GetVinVC(gomock.Any(), tokenID, gomock.Any()).Return(&core.VinVCLatest{Vin: vin}, nil)
+	s.jobs.EXPECT().Enqueue(gomock.Any(), core.OfferJobType, tokenID, vin, "CA", "", gomock.Any()).
+		Return(&core.ValuationJob{ID: "job4", Type: core.OfferJobType, TokenID: tokenID, Status: core.QueuedDataPullStatus}, nil)
+
+	request := dbtest.BuildRequest("POST", fmt.Sprintf("/vehicles/%d/instant-offer", tokenID), "")
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	body, _ := io.ReadAll(response.Body)
+
+	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
+	resp := JobQueuedResponse{}
+	require.NoError(s.T(), json.Unmarshal(body, &resp))
+	assert.Equal(s.T(), "job4", resp.JobID)
+	assert.Equal(s.T(), "instant offer request queued", resp.Message)
+}
+
+func (s *VehiclesControllerTestSuite) TestGetJob() {
+	tokenID := uint64(12350)
+	s.jobs.EXPECT().GetJob(gomock.Any(), "job5").Return(&core.ValuationJob{
+		ID:      "job5",
+		Type:    core.ValuationJobType,
+		TokenID: tokenID,
+		Status:  core.PulledValuationDrivlyStatus,
+	}, nil)
+
+	request := dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/jobs/job5", tokenID), "")
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	body, _ := io.ReadAll(response.Body)
+
+	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
+	job := core.ValuationJob{}
+	require.NoError(s.T(), json.Unmarshal(body, &job))
+	assert.Equal(s.T(), core.PulledValuationDrivlyStatus, job.Status)
+}
+
+func (s *VehiclesControllerTestSuite) TestGetJob_NotFound() {
+	tokenID := uint64(12351)
+	s.jobs.EXPECT().GetJob(gomock.Any(), "missing").Return(nil, services.ErrJobNotFound)
+	// job for another vehicle
+	s.jobs.EXPECT().GetJob(gomock.Any(), "job6").Return(&core.ValuationJob{ID: "job6", TokenID: 1}, nil)
+
+	for _, jobID := range []string{"missing", "job6"} {
+		request := dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/jobs/%s", tokenID, jobID), "")
+		response, err := s.app.Test(request)
+		require.NoError(s.T(), err)
+		assert.Equal(s.T(), fiber.StatusNotFound, response.StatusCode, jobID)
+	}
+}
+
 func (s *VehiclesControllerTestSuite) TestGetValuations_Drivly2() {
 	tokenID := uint64(12345)
 
-	s.identity.EXPECT().GetVehicle(tokenID).Return(&core.Vehicle{
+	s.identity.EXPECT().GetVehicle(gomock.Any(), tokenID).Return(&core.Vehicle{
 		ID: "xxx",
 		Definition: struct {
 			ID    string `json:"id"`
@@ -111,7 +252,7 @@ func (s *VehiclesControllerTestSuite) TestGetValuations_Drivly2() {
 		Owner: "0x123",
 	}, nil)
 
-	s.userDeviceSvc.EXPECT().GetValuations(gomock.Any(), tokenID, gomock.Any()).Return(&core.DeviceValuation{
+	s.userDeviceSvc.EXPECT().GetValuations(gomock.Any(), tokenID, gomock.Any(), "").Return(&core.DeviceValuation{
 		ValuationSets: []core.ValuationSet{
 			{
 				Vendor:           "drivly",
@@ -142,11 +283,121 @@ func (s *VehiclesControllerTestSuite) TestGetValuations_Drivly2() {
 	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
 }
 
+func (s *VehiclesControllerTestSuite) TestGetValuations_Currency() {
+	tokenID := uint64(12347)
+
+	s.identity.EXPECT().GetVehicle(gomock.Any(), tokenID).Return(&core.Vehicle{ID: "xxx", Owner: "0x123"}, nil).Times(2)
+	s.userDeviceSvc.EXPECT().GetValuations(gomock.Any(), tokenID, gomock.Any(), "EUR").Return(&core.DeviceValuation{
+		ValuationSets: []core.ValuationSet{{Vendor: "drivly", Retail: 50182, Currency: "EUR"}},
+	}, nil)
+	s.userDeviceSvc.EXPECT().GetValuations(gomock.Any(), tokenID, gomock.Any(), "XYZ").
+		Return(nil, errors.Wrap(services.ErrNoFxRate, "XYZ on 2024-10-18"))
+
+	request := dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations?currency=eur", tokenID), "")
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
+
+	request = dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations?currency=XYZ", tokenID), "")
+	response, err = s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+
+	request = dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations?currency=dollars", tokenID), "")
+	response, err = s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+}
+
+func (s *VehiclesControllerTestSuite) TestGetValuations_Units() {
+	tokenID := uint64(12348)
+
+	s.identity.EXPECT().GetVehicle(gomock.Any(), tokenID).Return(&core.Vehicle{ID: "xxx", Owner: "0x123"}, nil)
+	s.userDeviceSvc.EXPECT().GetValuations(gomock.Any(), tokenID, gomock.Any(), "").Return(&core.DeviceValuation{
+		ValuationSets: []core.ValuationSet{
+			{Vendor: "drivly", Mileage: 31069, Odometer: 31069, OdometerUnit: "miles"},
+			{Vendor: "vincario", Mileage: 50000, Odometer: 50000, OdometerUnit: "km"},
+		},
+	}, nil)
+
+	request := dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations?units=km", tokenID), "")
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	body, _ := io.ReadAll(response.Body)
+
+	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
+	valuations := core.DeviceValuation{}
+	require.NoError(s.T(), json.Unmarshal(body, &valuations))
+	for _, v := range valuations.ValuationSets {
+		assert.Equal(s.T(), "km", v.OdometerUnit)
+		// rounded to the nearest km
+		assert.InDelta(s.T(), 50000, v.Odometer, 1)
+		assert.InDelta(s.T(), 50000, v.Mileage, 1)
+	}
+
+	request = dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations?units=furlongs", tokenID), "")
+	response, err = s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+}
+
+func (s *VehiclesControllerTestSuite) TestGetValuationHistory() {
+	tokenID := uint64(12346)
+
+	s.identity.EXPECT().GetVehicle(gomock.Any(), tokenID).Return(&core.Vehicle{ID: "xxx", Owner: "0x123"}, nil)
+	s.userDeviceSvc.EXPECT().GetValuationHistory(gomock.Any(), tokenID, gomock.Any(), core.ValuationHistoryQuery{
+		From:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+		Cursor: "abc",
+		Limit:  10,
+	}).Return(&core.ValuationHistory{
+		ValuationSets: []core.ValuationSet{
+			{Vendor: "drivly", Retail: 55200, Updated: "2024-06-01T00:00:00Z"},
+			{Vendor: "drivly", Retail: 57100, Updated: "2024-02-01T00:00:00Z"},
+		},
+		NextCursor: "def",
+	}, nil)
+
+	request := dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations/history?from=2024-01-01T00:00:00Z&to=2024-07-01T00:00:00Z&cursor=abc&limit=10", tokenID), "")
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	body, _ := io.ReadAll(response.Body)
+
+	assert.Equal(s.T(), fiber.StatusOK, response.StatusCode)
+	history := core.ValuationHistory{}
+	require.NoError(s.T(), json.Unmarshal(body, &history))
+	assert.Len(s.T(), history.ValuationSets, 2)
+	assert.Equal(s.T(), "def", history.NextCursor)
+}
+
+func (s *VehiclesControllerTestSuite) TestGetValuationHistory_InvalidParams() {
+	tokenID := uint64(12346)
+
+	request := dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations/history?from=yesterday", tokenID), "")
+	response, err := s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+
+	request = dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations/history?from=2024-07-01T00:00:00Z&to=2024-01-01T00:00:00Z", tokenID), "")
+	response, err = s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+
+	s.identity.EXPECT().GetVehicle(gomock.Any(), tokenID).Return(&core.Vehicle{ID: "xxx", Owner: "0x123"}, nil)
+	s.userDeviceSvc.EXPECT().GetValuationHistory(gomock.Any(), tokenID, gomock.Any(), gomock.Any()).
+		Return(nil, services.ErrInvalidCursor)
+
+	request = dbtest.BuildRequest("GET", fmt.Sprintf("/vehicles/%d/valuations/history?cursor=nope", tokenID), "")
+	response, err = s.app.Test(request)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), fiber.StatusBadRequest, response.StatusCode)
+}
+
 func (s *VehiclesControllerTestSuite) TestGetOffers() {
 
 	tokenID := uint64(12345)
 
-	s.identity.EXPECT().GetVehicle(tokenID).Return(&core.Vehicle{
+	s.identity.EXPECT().GetVehicle(gomock.Any(), tokenID).Return(&core.Vehicle{
 		ID: "xxx",
 		Definition: struct {
 			ID    string `json:"id"`
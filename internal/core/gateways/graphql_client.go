@@ -0,0 +1,96 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// graphQLClient posts graphql queries, cancelled with the caller's context. 404 is ErrNotFound and 400 ErrBadRequest.
+// Failed queries are not retried, callers decide whether to carry on without the data.
+type graphQLClient struct {
+	httpClient *http.Client
+	url        string
+	headers    map[string]string
+}
+
+func newGraphQLClient(url string, timeout time.Duration) *graphQLClient {
+	return &graphQLClient{
+		httpClient: &http.Client{Timeout: timeout},
+		url:        url,
+		headers:    map[string]string{"Accept": "application/json", "Content-Type": "application/json"},
+	}
+}
+
+// Query posts the query and decodes the response into result. authHeader is sent as is when set, eg. Bearer xxx
+func (g *graphQLClient) Query(ctx context.Context, authHeader, query string, result interface{}) error {
+	payload, err := json.Marshal(struct {
+		Query string `json:"query"`
+	}{Query: query})
+	if err != nil {
+		return err
+	}
+
+	body, _, err := g.post(ctx, authHeader, payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, result)
+}
+
+// Ping whether the api is reachable, any response short of a 5xx counts
+func (g *graphQLClient) Ping(ctx context.Context) error {
+	payload, err := json.Marshal(struct {
+		Query string `json:"query"`
+	}{Query: "{ __typename }"})
+	if err != nil {
+		return err
+	}
+	_, unreachable, err := g.post(ctx, "", payload)
+	if unreachable {
+		return err
+	}
+	return nil
+}
+
+// post the payload, whether the api was unreachable or failed (connection error or 5xx) on error
+func (g *graphQLClient) post(ctx context.Context, authHeader string, payload []byte) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, false, err
+	}
+	for k, v := range g.headers {
+		req.Header.Set(k, v)
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	res, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "error calling %s", g.url)
+	}
+	defer res.Body.Close() // nolint
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "error reading response body from %s", g.url)
+	}
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, false, ErrNotFound
+	case res.StatusCode == http.StatusBadRequest:
+		return nil, false, ErrBadRequest
+	case res.StatusCode >= 500:
+		return nil, true, fmt.Errorf("received status code %d from %s with body: %s", res.StatusCode, g.url, body)
+	case res.StatusCode > 299:
+		return nil, false, fmt.Errorf("received status code %d from %s with body: %s", res.StatusCode, g.url, body)
+	}
+	return body, false, nil
+}
@@ -1,12 +1,12 @@
 package gateways
 
 import (
+	"context"
 	"strconv"
 	"time"
 
 	coremodels "github.com/DIMO-Network/valuations-api/internal/core/models"
 
-	"github.com/DIMO-Network/shared/pkg/http"
 	"github.com/DIMO-Network/valuations-api/internal/config"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -16,29 +16,31 @@ var ErrNotFound = errors.New("not found")
 var ErrBadRequest = errors.New("bad request")
 
 type identityAPIService struct {
-	httpClient http.ClientWrapper
+	httpClient *graphQLClient
 	logger     zerolog.Logger
 }
 
 //go:generate mockgen -source identity_api.go -destination mocks/identity_api_mock.go -package mock_gateways
 type IdentityAPI interface {
-	GetManufacturer(slug string) (*coremodels.Manufacturer, error)
-	GetDefinition(definitionID string) (*coremodels.DeviceDefinition, error)
-	GetVehicle(tokenID uint64) (*coremodels.Vehicle, error)
+	GetManufacturer(ctx context.Context, slug string) (*coremodels.Manufacturer, error)
+	GetDefinition(ctx context.Context, definitionID string) (*coremodels.DeviceDefinition, error)
+	GetVehicle(ctx context.Context, tokenID uint64) (*coremodels.Vehicle, error)
+	// GetOwnerVehicles every vehicle owned by the ethereum address
+	GetOwnerVehicles(ctx context.Context, owner string) ([]coremodels.Vehicle, error)
+	// Ping errors when identity-api is unreachable
+	Ping(ctx context.Context) error
 }
 
 // NewIdentityAPIService creates a new instance of IdentityAPI, initializing it with the provided logger, settings, and HTTP client.
 // httpClient is used for testing really
 func NewIdentityAPIService(logger *zerolog.Logger, settings *config.Settings) IdentityAPI {
-	httpClient, _ := http.NewClientWrapper(settings.IdentityAPIURL.String(), "", 10*time.Second, nil, true) // ok to ignore err since only used for tor check
-
 	return &identityAPIService{
-		httpClient: httpClient,
+		httpClient: newGraphQLClient(settings.IdentityAPIURL.String(), 10*time.Second),
 		logger:     *logger,
 	}
 }
 
-func (i *identityAPIService) GetVehicle(tokenID uint64) (*coremodels.Vehicle, error) {
+func (i *identityAPIService) GetVehicle(ctx context.Context, tokenID uint64) (*coremodels.Vehicle, error) {
 	query := `{
   vehicle(tokenId: ` + strconv.FormatUint(tokenID, 10) + `) {
     id
@@ -56,7 +58,7 @@ func (i *identityAPIService) GetVehicle(tokenID uint64) (*coremodels.Vehicle, er
 			Vehicle coremodels.Vehicle `json:"vehicle"`
 		} `json:"data"`
 	}
-	err := i.httpClient.GraphQLQuery("", query, &wrapper)
+	err := i.httpClient.Query(ctx, "", query, &wrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +68,7 @@ func (i *identityAPIService) GetVehicle(tokenID uint64) (*coremodels.Vehicle, er
 	return &wrapper.Data.Vehicle, nil
 }
 
-func (i *identityAPIService) GetDefinition(definitionID string) (*coremodels.DeviceDefinition, error) {
+func (i *identityAPIService) GetDefinition(ctx context.Context, definitionID string) (*coremodels.DeviceDefinition, error) {
 	query := `{
   deviceDefinition(by: {id: "` + definitionID + `"}) {
     model,
@@ -88,7 +90,7 @@ func (i *identityAPIService) GetDefinition(definitionID string) (*coremodels.Dev
 			DeviceDefinition coremodels.DeviceDefinition `json:"deviceDefinition"`
 		} `json:"data"`
 	}
-	err := i.httpClient.GraphQLQuery("", query, &wrapper)
+	err := i.httpClient.Query(ctx, "", query, &wrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +101,7 @@ func (i *identityAPIService) GetDefinition(definitionID string) (*coremodels.Dev
 }
 
 // GetManufacturer from identity-api by the name - must match exactly. Returns the token id and other on chain info
-func (i *identityAPIService) GetManufacturer(name string) (*coremodels.Manufacturer, error) {
+func (i *identityAPIService) GetManufacturer(ctx context.Context, name string) (*coremodels.Manufacturer, error) {
 	query := `{
   manufacturer(by: {name: "` + name + `"}) {
     	tokenId
@@ -113,7 +115,7 @@ func (i *identityAPIService) GetManufacturer(name string) (*coremodels.Manufactu
 			Manufacturer coremodels.Manufacturer `json:"manufacturer"`
 		} `json:"data"`
 	}
-	err := i.httpClient.GraphQLQuery("", query, &wrapper)
+	err := i.httpClient.Query(ctx, "", query, &wrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -122,3 +124,61 @@ func (i *identityAPIService) GetManufacturer(name string) (*coremodels.Manufactu
 	}
 	return &wrapper.Data.Manufacturer, nil
 }
+
+// ownerVehiclesPageSize max page size identity-api allows
+const ownerVehiclesPageSize = 100
+
+func (i *identityAPIService) GetOwnerVehicles(ctx context.Context, owner string) ([]coremodels.Vehicle, error) {
+	var vehicles []coremodels.Vehicle
+	after := ""
+	for {
+		afterArg := ""
+		if after != "" {
+			afterArg = `, after: "` + after + `"`
+		}
+		query := `{
+  vehicles(first: ` + strconv.Itoa(ownerVehiclesPageSize) + afterArg + `, filterBy: {owner: "` + owner + `"}) {
+    nodes {
+      id
+      tokenId
+      definition{
+        id
+        make
+        model
+        year
+      }
+      owner
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+		var wrapper struct {
+			Data struct {
+				Vehicles struct {
+					Nodes    []coremodels.Vehicle `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"vehicles"`
+			} `json:"data"`
+		}
+		err := i.httpClient.Query(ctx, "", query, &wrapper)
+		if err != nil {
+			return nil, err
+		}
+		vehicles = append(vehicles, wrapper.Data.Vehicles.Nodes...)
+		if !wrapper.Data.Vehicles.PageInfo.HasNextPage || wrapper.Data.Vehicles.PageInfo.EndCursor == "" {
+			break
+		}
+		after = wrapper.Data.Vehicles.PageInfo.EndCursor
+	}
+	return vehicles, nil
+}
+
+func (i *identityAPIService) Ping(ctx context.Context) error {
+	return i.httpClient.Ping(ctx)
+}
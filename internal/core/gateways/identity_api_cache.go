@@ -0,0 +1,197 @@
+package gateways
+
+import (
+	"container/list"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/appmetrics"
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	coremodels "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultIdentityCacheTTL         = 5 * time.Minute
+	defaultIdentityCacheNegativeTTL = time.Minute
+	defaultIdentityCacheSize        = 10000
+)
+
+// outcomes of cached lookups, the result label of the identity cache counter
+const (
+	cacheHit         = "hit"
+	cacheNegativeHit = "negative_hit"
+	cacheMiss        = "miss"
+)
+
+// cachedIdentityAPI caches vehicle, definition and manufacturer lookups, including not found ones for a shorter ttl.
+// Concurrent lookups of the same key share a single call to identity-api. Owner vehicles are not cached since they
+// change with transfers. Returned values are copies of the cached top level struct, slices in them are shared.
+type cachedIdentityAPI struct {
+	next          IdentityAPI
+	vehicles      *lruCache[uint64, coremodels.Vehicle]
+	definitions   *lruCache[string, coremodels.DeviceDefinition]
+	manufacturers *lruCache[string, coremodels.Manufacturer]
+	group         singleflight.Group
+}
+
+// NewCachedIdentityAPI wraps the IdentityAPI with a cache, sized and expired per the IDENTITY_CACHE settings
+func NewCachedIdentityAPI(next IdentityAPI, settings *config.Settings) (IdentityAPI, error) {
+	ttl, err := parseCacheDuration(settings.IdentityCacheTTL, defaultIdentityCacheTTL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid IDENTITY_CACHE_TTL %s", settings.IdentityCacheTTL)
+	}
+	negativeTTL, err := parseCacheDuration(settings.IdentityCacheNegativeTTL, defaultIdentityCacheNegativeTTL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid IDENTITY_CACHE_NEGATIVE_TTL %s", settings.IdentityCacheNegativeTTL)
+	}
+	size := settings.IdentityCacheSize
+	if size <= 0 {
+		size = defaultIdentityCacheSize
+	}
+	return &cachedIdentityAPI{
+		next:          next,
+		vehicles:      newLRUCache[uint64, coremodels.Vehicle](size, ttl, negativeTTL),
+		definitions:   newLRUCache[string, coremodels.DeviceDefinition](size, ttl, negativeTTL),
+		manufacturers: newLRUCache[string, coremodels.Manufacturer](size, ttl, negativeTTL),
+	}, nil
+}
+
+func parseCacheDuration(value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func (c *cachedIdentityAPI) GetVehicle(ctx context.Context, tokenID uint64) (*coremodels.Vehicle, error) {
+	return cachedLookup(ctx, &c.group, c.vehicles, "vehicle", tokenID, strconv.FormatUint(tokenID, 10), c.next.GetVehicle)
+}
+
+func (c *cachedIdentityAPI) GetDefinition(ctx context.Context, definitionID string) (*coremodels.DeviceDefinition, error) {
+	return cachedLookup(ctx, &c.group, c.definitions, "definition", definitionID, definitionID, c.next.GetDefinition)
+}
+
+func (c *cachedIdentityAPI) GetManufacturer(ctx context.Context, slug string) (*coremodels.Manufacturer, error) {
+	return cachedLookup(ctx, &c.group, c.manufacturers, "manufacturer", slug, slug, c.next.GetManufacturer)
+}
+
+func (c *cachedIdentityAPI) GetOwnerVehicles(ctx context.Context, owner string) ([]coremodels.Vehicle, error) {
+	return c.next.GetOwnerVehicles(ctx, owner)
+}
+
+func (c *cachedIdentityAPI) Ping(ctx context.Context) error {
+	return c.next.Ping(ctx)
+}
+
+// cachedLookup the cached value or error for the key, otherwise looks it up once for all concurrent callers. The
+// lookup isn't cancelled when the first caller gives up, the others may still be waiting on it
+func cachedLookup[K comparable, V any](ctx context.Context, group *singleflight.Group, cache *lruCache[K, V], kind string,
+	key K, flightKey string, lookup func(ctx context.Context, key K) (*V, error)) (*V, error) {
+	if entry, ok := cache.get(key); ok {
+		if entry.err != nil {
+			appmetrics.IdentityCacheCount.WithLabelValues(kind, cacheNegativeHit).Inc()
+			return nil, entry.err
+		}
+		appmetrics.IdentityCacheCount.WithLabelValues(kind, cacheHit).Inc()
+		return &entry.value, nil
+	}
+	appmetrics.IdentityCacheCount.WithLabelValues(kind, cacheMiss).Inc()
+
+	result := group.DoChan(kind+":"+flightKey, func() (interface{}, error) {
+		value, err := lookup(context.WithoutCancel(ctx), key)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			cache.setNotFound(key, err)
+		case err == nil:
+			cache.set(key, *value)
+		}
+		return value, err
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		value := *res.Val.(*V)
+		return &value, nil
+	}
+}
+
+type lruEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	err     error
+	expires time.Time
+}
+
+// lruCache evicts the least recently used entry past its size, and entries past their ttl. Not found errors are kept
+// for the negative ttl.
+type lruCache[K comparable, V any] struct {
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	order   *list.List
+}
+
+func newLRUCache[K comparable, V any](size int, ttl, negativeTTL time.Duration) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		size:        size,
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		now:         time.Now,
+		entries:     make(map[K]*list.Element, size),
+		order:       list.New(),
+	}
+}
+
+// get a copy of the entry, with either the value or the not found error, and whether the key is cached
+func (l *lruCache[K, V]) get(key K) (lruEntry[K, V], bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	elem, ok := l.entries[key]
+	if !ok {
+		return lruEntry[K, V]{}, false
+	}
+	entry := elem.Value.(*lruEntry[K, V])
+	if !l.now().Before(entry.expires) {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+		return lruEntry[K, V]{}, false
+	}
+	l.order.MoveToFront(elem)
+	return *entry, true
+}
+
+func (l *lruCache[K, V]) set(key K, value V) {
+	l.put(&lruEntry[K, V]{key: key, value: value, expires: l.now().Add(l.ttl)})
+}
+
+func (l *lruCache[K, V]) setNotFound(key K, err error) {
+	l.put(&lruEntry[K, V]{key: key, err: err, expires: l.now().Add(l.negativeTTL)})
+}
+
+func (l *lruCache[K, V]) put(entry *lruEntry[K, V]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem, ok := l.entries[entry.key]; ok {
+		elem.Value = entry
+		l.order.MoveToFront(elem)
+		return
+	}
+	l.entries[entry.key] = l.order.PushFront(entry)
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
@@ -0,0 +1,115 @@
+package gateways
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	coremodels "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingIdentityAPI finds vehicles with an even token id, blocking each lookup until release is closed
+type countingIdentityAPI struct {
+	IdentityAPI
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (f *countingIdentityAPI) GetVehicle(_ context.Context, tokenID uint64) (*coremodels.Vehicle, error) {
+	f.calls.Add(1)
+	<-f.release
+	if tokenID%2 == 1 {
+		return nil, errors.Wrapf(ErrNotFound, "vehicle %d", tokenID)
+	}
+	return &coremodels.Vehicle{ID: "v", TokenID: tokenID}, nil
+}
+
+func newTestCachedIdentityAPI(t *testing.T, size int) (*cachedIdentityAPI, *countingIdentityAPI, *time.Time) {
+	next := &countingIdentityAPI{release: make(chan struct{})}
+	close(next.release)
+	api, err := NewCachedIdentityAPI(next, &config.Settings{IdentityCacheTTL: "5m", IdentityCacheNegativeTTL: "1m", IdentityCacheSize: size})
+	require.NoError(t, err)
+	cached := api.(*cachedIdentityAPI)
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	cached.vehicles.now = func() time.Time { return now }
+	return cached, next, &now
+}
+
+func TestCachedIdentityAPI_GetVehicle(t *testing.T) {
+	api, next, now := newTestCachedIdentityAPI(t, 10)
+	ctx := context.Background()
+
+	vehicle, err := api.GetVehicle(ctx, 2)
+	require.NoError(t, err)
+	vehicle.Owner = "changed by caller"
+	again, err := api.GetVehicle(ctx, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), next.calls.Load())
+	assert.Empty(t, again.Owner, "callers get copies")
+
+	*now = now.Add(5 * time.Minute)
+	_, err = api.GetVehicle(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), next.calls.Load(), "looked up again after the ttl")
+}
+
+func TestCachedIdentityAPI_GetVehicle_notFound(t *testing.T) {
+	api, next, now := newTestCachedIdentityAPI(t, 10)
+	ctx := context.Background()
+
+	_, err := api.GetVehicle(ctx, 3)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	_, err = api.GetVehicle(ctx, 3)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Equal(t, int32(1), next.calls.Load(), "not found is cached")
+
+	*now = now.Add(time.Minute)
+	_, _ = api.GetVehicle(ctx, 3)
+	assert.Equal(t, int32(2), next.calls.Load(), "looked up again after the negative ttl")
+}
+
+func TestCachedIdentityAPI_GetVehicle_evictsLeastRecentlyUsed(t *testing.T) {
+	api, next, _ := newTestCachedIdentityAPI(t, 2)
+	ctx := context.Background()
+
+	for _, tokenID := range []uint64{2, 4, 2, 6, 2} {
+		_, err := api.GetVehicle(ctx, tokenID)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), next.calls.Load())
+
+	_, _ = api.GetVehicle(ctx, 4)
+	assert.Equal(t, int32(4), next.calls.Load(), "4 was evicted when 6 was added")
+}
+
+func TestCachedIdentityAPI_GetVehicle_concurrent(t *testing.T) {
+	api, next, _ := newTestCachedIdentityAPI(t, 10)
+	next.release = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.GetVehicle(context.Background(), 2)
+			assert.NoError(t, err)
+		}()
+	}
+	require.Eventually(t, func() bool { return next.calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), next.calls.Load(), "concurrent lookups share one call")
+}
+
+func TestNewCachedIdentityAPI_invalidTTL(t *testing.T) {
+	_, err := NewCachedIdentityAPI(&countingIdentityAPI{}, &config.Settings{IdentityCacheTTL: "five minutes"})
+	assert.Error(t, err)
+}
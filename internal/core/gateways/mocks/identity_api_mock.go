@@ -10,6 +10,7 @@
 package mock_gateways
 
 import (
+	context "context"
 	reflect "reflect"
 
 	models "github.com/DIMO-Network/valuations-api/internal/core/models"
@@ -40,46 +41,75 @@ func (m *MockIdentityAPI) EXPECT() *MockIdentityAPIMockRecorder {
 }
 
 // GetDefinition mocks base method.
-func (m *MockIdentityAPI) GetDefinition(definitionID string) (*models.DeviceDefinition, error) {
+func (m *MockIdentityAPI) GetDefinition(ctx context.Context, definitionID string) (*models.DeviceDefinition, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDefinition", definitionID)
+	ret := m.ctrl.Call(m, "GetDefinition", ctx, definitionID)
 	ret0, _ := ret[0].(*models.DeviceDefinition)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetDefinition indicates an expected call of GetDefinition.
-func (mr *MockIdentityAPIMockRecorder) GetDefinition(definitionID any) *gomock.Call {
+func (mr *MockIdentityAPIMockRecorder) GetDefinition(ctx, definitionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefinition", reflect.TypeOf((*MockIdentityAPI)(nil).GetDefinition), definitionID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefinition", reflect.TypeOf((*MockIdentityAPI)(nil).GetDefinition), ctx, definitionID)
 }
 
 // GetManufacturer mocks base method.
-func (m *MockIdentityAPI) GetManufacturer(slug string) (*models.Manufacturer, error) {
+func (m *MockIdentityAPI) GetManufacturer(ctx context.Context, slug string) (*models.Manufacturer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetManufacturer", slug)
+	ret := m.ctrl.Call(m, "GetManufacturer", ctx, slug)
 	ret0, _ := ret[0].(*models.Manufacturer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetManufacturer indicates an expected call of GetManufacturer.
-func (mr *MockIdentityAPIMockRecorder) GetManufacturer(slug any) *gomock.Call {
+func (mr *MockIdentityAPIMockRecorder) GetManufacturer(ctx, slug any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManufacturer", reflect.TypeOf((*MockIdentityAPI)(nil).GetManufacturer), slug)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManufacturer", reflect.TypeOf((*MockIdentityAPI)(nil).GetManufacturer), ctx, slug)
+}
+
+// GetOwnerVehicles mocks base method.
+func (m *MockIdentityAPI) GetOwnerVehicles(ctx context.Context, owner string) ([]models.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerVehicles", ctx, owner)
+	ret0, _ := ret[0].([]models.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerVehicles indicates an expected call of GetOwnerVehicles.
+func (mr *MockIdentityAPIMockRecorder) GetOwnerVehicles(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerVehicles", reflect.TypeOf((*MockIdentityAPI)(nil).GetOwnerVehicles), ctx, owner)
 }
 
 // GetVehicle mocks base method.
-func (m *MockIdentityAPI) GetVehicle(tokenID uint64) (*models.Vehicle, error) {
+func (m *MockIdentityAPI) GetVehicle(ctx context.Context, tokenID uint64) (*models.Vehicle, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVehicle", tokenID)
+	ret := m.ctrl.Call(m, "GetVehicle", ctx, tokenID)
 	ret0, _ := ret[0].(*models.Vehicle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVehicle indicates an expected call of GetVehicle.
-func (mr *MockIdentityAPIMockRecorder) GetVehicle(tokenID any) *gomock.Call {
+func (mr *MockIdentityAPIMockRecorder) GetVehicle(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicle", reflect.TypeOf((*MockIdentityAPI)(nil).GetVehicle), ctx, tokenID)
+}
+
+// Ping mocks base method.
+func (m *MockIdentityAPI) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockIdentityAPIMockRecorder) Ping(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicle", reflect.TypeOf((*MockIdentityAPI)(nil).GetVehicle), tokenID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIdentityAPI)(nil).Ping), ctx)
 }
@@ -10,7 +10,9 @@
 package mock_gateways
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/DIMO-Network/valuations-api/internal/core/models"
 	gomock "go.uber.org/mock/gomock"
@@ -39,32 +41,76 @@ func (m *MockTelemetryAPI) EXPECT() *MockTelemetryAPIMockRecorder {
 	return m.recorder
 }
 
+// GetConditionSignals mocks base method.
+func (m *MockTelemetryAPI) GetConditionSignals(ctx context.Context, tokenID uint64, authHeader string) (*models.ConditionSignals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConditionSignals", ctx, tokenID, authHeader)
+	ret0, _ := ret[0].(*models.ConditionSignals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConditionSignals indicates an expected call of GetConditionSignals.
+func (mr *MockTelemetryAPIMockRecorder) GetConditionSignals(ctx, tokenID, authHeader any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConditionSignals", reflect.TypeOf((*MockTelemetryAPI)(nil).GetConditionSignals), ctx, tokenID, authHeader)
+}
+
 // GetLatestSignals mocks base method.
-func (m *MockTelemetryAPI) GetLatestSignals(tokenID uint64, authHeader string) (*models.SignalsLatest, error) {
+func (m *MockTelemetryAPI) GetLatestSignals(ctx context.Context, tokenID uint64, authHeader string) (*models.SignalsLatest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLatestSignals", tokenID, authHeader)
+	ret := m.ctrl.Call(m, "GetLatestSignals", ctx, tokenID, authHeader)
 	ret0, _ := ret[0].(*models.SignalsLatest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLatestSignals indicates an expected call of GetLatestSignals.
-func (mr *MockTelemetryAPIMockRecorder) GetLatestSignals(tokenID, authHeader any) *gomock.Call {
+func (mr *MockTelemetryAPIMockRecorder) GetLatestSignals(ctx, tokenID, authHeader any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSignals", reflect.TypeOf((*MockTelemetryAPI)(nil).GetLatestSignals), ctx, tokenID, authHeader)
+}
+
+// GetOdometerHistory mocks base method.
+func (m *MockTelemetryAPI) GetOdometerHistory(ctx context.Context, tokenID uint64, authHeader string, from, to time.Time) ([]models.TimeFloatValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOdometerHistory", ctx, tokenID, authHeader, from, to)
+	ret0, _ := ret[0].([]models.TimeFloatValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOdometerHistory indicates an expected call of GetOdometerHistory.
+func (mr *MockTelemetryAPIMockRecorder) GetOdometerHistory(ctx, tokenID, authHeader, from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSignals", reflect.TypeOf((*MockTelemetryAPI)(nil).GetLatestSignals), tokenID, authHeader)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOdometerHistory", reflect.TypeOf((*MockTelemetryAPI)(nil).GetOdometerHistory), ctx, tokenID, authHeader, from, to)
 }
 
 // GetVinVC mocks base method.
-func (m *MockTelemetryAPI) GetVinVC(tokenID uint64, authHeader string) (*models.VinVCLatest, error) {
+func (m *MockTelemetryAPI) GetVinVC(ctx context.Context, tokenID uint64, authHeader string) (*models.VinVCLatest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVinVC", tokenID, authHeader)
+	ret := m.ctrl.Call(m, "GetVinVC", ctx, tokenID, authHeader)
 	ret0, _ := ret[0].(*models.VinVCLatest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVinVC indicates an expected call of GetVinVC.
-func (mr *MockTelemetryAPIMockRecorder) GetVinVC(tokenID, authHeader any) *gomock.Call {
+func (mr *MockTelemetryAPIMockRecorder) GetVinVC(ctx, tokenID, authHeader any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVinVC", reflect.TypeOf((*MockTelemetryAPI)(nil).GetVinVC), ctx, tokenID, authHeader)
+}
+
+// Ping mocks base method.
+func (m *MockTelemetryAPI) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockTelemetryAPIMockRecorder) Ping(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVinVC", reflect.TypeOf((*MockTelemetryAPI)(nil).GetVinVC), tokenID, authHeader)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockTelemetryAPI)(nil).Ping), ctx)
 }
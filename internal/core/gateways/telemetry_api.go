@@ -1,11 +1,10 @@
 package gateways
 
 import (
+	"context"
 	"strconv"
 	"time"
 
-	"github.com/DIMO-Network/shared/pkg/http"
-
 	"github.com/DIMO-Network/valuations-api/internal/config"
 	coremodels "github.com/DIMO-Network/valuations-api/internal/core/models"
 	"github.com/pkg/errors"
@@ -14,27 +13,29 @@ import (
 
 type telemetryAPIService struct {
 	logger     zerolog.Logger
-	httpClient http.ClientWrapper
+	httpClient *graphQLClient
 }
 
 //go:generate mockgen -source telemetry_api.go -destination mocks/telemetry_api_mock.go -package mock_gateways
 type TelemetryAPI interface {
-	GetLatestSignals(tokenID uint64, authHeader string) (*coremodels.SignalsLatest, error)
-	GetVinVC(tokenID uint64, authHeader string) (*coremodels.VinVCLatest, error)
+	GetLatestSignals(ctx context.Context, tokenID uint64, authHeader string) (*coremodels.SignalsLatest, error)
+	GetVinVC(ctx context.Context, tokenID uint64, authHeader string) (*coremodels.VinVCLatest, error)
+	GetConditionSignals(ctx context.Context, tokenID uint64, authHeader string) (*coremodels.ConditionSignals, error)
+	// GetOdometerHistory daily max odometer, in km, between from and to. Days without a reading are left out
+	GetOdometerHistory(ctx context.Context, tokenID uint64, authHeader string, from, to time.Time) ([]coremodels.TimeFloatValue, error)
+	// Ping errors when telemetry-api is unreachable
+	Ping(ctx context.Context) error
 }
 
 func NewTelemetryAPI(logger *zerolog.Logger, settings *config.Settings) TelemetryAPI {
-	h := map[string]string{}
-	httpClient, _ := http.NewClientWrapper(settings.TelemetryAPIURL.String(), "", 10*time.Second, h, true) // ok to ignore err since only used for tor check
-
 	return &telemetryAPIService{
 		logger:     *logger,
-		httpClient: httpClient,
+		httpClient: newGraphQLClient(settings.TelemetryAPIURL.String(), 10*time.Second),
 	}
 }
 
 // GetVinVC gets the VIN. authHeader must be full string with Bearer xxx
-func (i *telemetryAPIService) GetVinVC(tokenID uint64, authHeader string) (*coremodels.VinVCLatest, error) {
+func (i *telemetryAPIService) GetVinVC(ctx context.Context, tokenID uint64, authHeader string) (*coremodels.VinVCLatest, error) {
 	query := `{
 vinVCLatest(tokenId:` + strconv.FormatUint(tokenID, 10) + `) {
     vin
@@ -51,7 +52,7 @@ vinVCLatest(tokenId:` + strconv.FormatUint(tokenID, 10) + `) {
 			VinVCLatest coremodels.VinVCLatest `json:"vinVCLatest"`
 		} `json:"data"`
 	}
-	err := i.httpClient.GraphQLQuery(authHeader, query, &wrapper)
+	err := i.httpClient.Query(ctx, authHeader, query, &wrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +64,7 @@ vinVCLatest(tokenId:` + strconv.FormatUint(tokenID, 10) + `) {
 }
 
 // GetLatestSignals odometer and location. authHeader must be full string with Bearer xxx
-func (i *telemetryAPIService) GetLatestSignals(tokenID uint64, authHeader string) (*coremodels.SignalsLatest, error) {
+func (i *telemetryAPIService) GetLatestSignals(ctx context.Context, tokenID uint64, authHeader string) (*coremodels.SignalsLatest, error) {
 	query := `{
 signalsLatest(tokenId:` + strconv.FormatUint(tokenID, 10) + `) {
 		powertrainTransmissionTravelledDistance {
@@ -86,10 +87,104 @@ signalsLatest(tokenId:` + strconv.FormatUint(tokenID, 10) + `) {
 			SignalsLatest coremodels.SignalsLatest `json:"signalsLatest"`
 		} `json:"data"`
 	}
-	err := i.httpClient.GraphQLQuery(authHeader, query, &wrapper)
+	err := i.httpClient.Query(ctx, authHeader, query, &wrapper)
 	if err != nil {
 		return nil, err
 	}
 
 	return &wrapper.Data.SignalsLatest, nil
 }
+
+// GetConditionSignals diagnostic and EV battery signals for scoring the vehicle condition. A separate query from GetLatestSignals so the
+// odometer and location are still available if the condition signals can't be queried. authHeader must be full string
+// with Bearer xxx
+func (i *telemetryAPIService) GetConditionSignals(ctx context.Context, tokenID uint64, authHeader string) (*coremodels.ConditionSignals, error) {
+	query := `{
+signalsLatest(tokenId:` + strconv.FormatUint(tokenID, 10) + `) {
+		obdDTCList {
+			timestamp
+			value
+		}
+		powertrainTractionBatteryStateOfHealth {
+			timestamp
+			value
+		}
+		powertrainTractionBatteryGrossCapacity {
+			timestamp
+			value
+		}
+		lowVoltageBatteryCurrentVoltage {
+			timestamp
+			value
+		}
+		chassisAxleRow1WheelLeftTirePressure {
+			timestamp
+			value
+		}
+		chassisAxleRow1WheelRightTirePressure {
+			timestamp
+			value
+		}
+		chassisAxleRow2WheelLeftTirePressure {
+			timestamp
+			value
+		}
+		chassisAxleRow2WheelRightTirePressure {
+			timestamp
+			value
+		}
+		serviceDistanceToService {
+			timestamp
+			value
+		}
+	}
+}`
+
+	var wrapper struct {
+		Data struct {
+			SignalsLatest coremodels.ConditionSignals `json:"signalsLatest"`
+		} `json:"data"`
+	}
+	err := i.httpClient.Query(ctx, authHeader, query, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wrapper.Data.SignalsLatest, nil
+}
+
+// GetOdometerHistory daily max odometer, in km, between from and to. authHeader must be full string with Bearer xxx
+func (i *telemetryAPIService) GetOdometerHistory(ctx context.Context, tokenID uint64, authHeader string, from, to time.Time) ([]coremodels.TimeFloatValue, error) {
+	query := `{
+signals(tokenId:` + strconv.FormatUint(tokenID, 10) + `, interval: "24h", from: "` + from.UTC().Format(time.RFC3339) +
+		`", to: "` + to.UTC().Format(time.RFC3339) + `") {
+		timestamp
+		powertrainTransmissionTravelledDistance(agg: MAX)
+	}
+}`
+
+	var wrapper struct {
+		Data struct {
+			Signals []struct {
+				Timestamp                               time.Time `json:"timestamp"`
+				PowertrainTransmissionTravelledDistance *float64  `json:"powertrainTransmissionTravelledDistance"`
+			} `json:"signals"`
+		} `json:"data"`
+	}
+	err := i.httpClient.Query(ctx, authHeader, query, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []coremodels.TimeFloatValue
+	for _, s := range wrapper.Data.Signals {
+		if s.PowertrainTransmissionTravelledDistance != nil {
+			history = append(history, coremodels.TimeFloatValue{Timestamp: s.Timestamp, Value: *s.PowertrainTransmissionTravelledDistance})
+		}
+	}
+	return history, nil
+}
+
+func (i *telemetryAPIService) Ping(ctx context.Context) error {
+	return i.httpClient.Ping(ctx)
+}
@@ -0,0 +1,32 @@
+package models
+
+// ConditionTier condition the vehicle is valued in, matching the tiers the books price by
+type ConditionTier string
+
+const (
+	CleanCondition   ConditionTier = "clean"
+	AverageCondition ConditionTier = "average"
+	RoughCondition   ConditionTier = "rough"
+)
+
+// String returns the string representation of the ConditionTier.
+func (ct ConditionTier) String() string {
+	return string(ct)
+}
+
+// ConditionAssessment condition of the vehicle scored from its diagnostic signals
+type ConditionAssessment struct {
+	Tier ConditionTier `json:"tier"`
+	// Score out of 100, deductions are made for each problem found
+	Score int `json:"score"`
+	// Deductions the problems found, eg. 2 diagnostic trouble codes
+	Deductions []string `json:"deductions,omitempty"`
+}
+
+// BatteryHealth EV traction battery health when the valuation was requested, and the price adjustment for it
+type BatteryHealth struct {
+	// StateOfHealth percent of the original capacity
+	StateOfHealth float64 `json:"stateOfHealth"`
+	// AdjustmentPercent from the adjustment curve, applied to the prices, eg. -12
+	AdjustmentPercent float64 `json:"adjustmentPercent"`
+}
@@ -9,5 +9,9 @@ const (
 	PulledValuationDrivlyStatus   DataPullStatusEnum = "PulledValuations"
 	PulledValuationVincarioStatus DataPullStatusEnum = "PulledValuationVincario"
 	SkippedDataPullStatus         DataPullStatusEnum = "Skipped"
-	ErrorDataPullStatus           DataPullStatusEnum = "Error"
+	// QueuedDataPullStatus the request is waiting to be picked up by a worker
+	QueuedDataPullStatus DataPullStatusEnum = "Queued"
+	// ProcessingDataPullStatus a worker is pulling from the vendor
+	ProcessingDataPullStatus DataPullStatusEnum = "Processing"
+	ErrorDataPullStatus      DataPullStatusEnum = "Error"
 )
@@ -4,11 +4,12 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/DIMO-Network/valuations-api/internal/core/units"
 	"github.com/tidwall/gjson"
 )
 
 type DeviceOffer struct {
-	// Contains a list of offer sets, one for each source
+	// Contains a list of offer sets, newest first
 	OfferSets []OfferSet `json:"offerSets"`
 }
 type OfferSet struct {
@@ -18,12 +19,18 @@ type OfferSet struct {
 	Updated string `json:"updated,omitempty"`
 	// The mileage used for the offers
 	Mileage int `json:"mileage,omitempty"`
+	// OdometerUnit km or miles, the unit of mileage
+	OdometerUnit string `json:"odometerUnit,omitempty"`
 	// Whether estimated, real, or from the market (eg. vincario). Market, Estimated, Real
 	OdometerMeasurementType OdometerMeasurementEnum `json:"odometerMeasurementType"`
 	// This will be the zip code used (if any) for the offers request regardless if the source uses it
 	ZipCode string `json:"zipCode,omitempty"`
 	// Contains a list of offers from the source
 	Offers []Offer `json:"offers"`
+	// When the offers expire, offer URLs no longer work after this and a new instant offer must be requested
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	// Whether the offers have expired
+	Expired bool `json:"expired"`
 }
 type Offer struct {
 	// The vendor of the offer (eg. "carmax", "carvana", etc.)
@@ -40,6 +47,16 @@ type Offer struct {
 	DeclineReason string `json:"declineReason,omitempty"`
 }
 
+// ConvertDistances converts mileage to the unit. Left as is when the set's unit is unknown
+func (o *OfferSet) ConvertDistances(unit units.DistanceUnit) {
+	from, err := units.ParseDistanceUnit(o.OdometerUnit)
+	if err != nil {
+		return
+	}
+	o.Mileage = convertDistance(o.Mileage, from, unit)
+	o.OdometerUnit = unit.String()
+}
+
 func DecodeOfferFromJSON(drivlyJSON []byte) OfferSet {
 	drivlyOffers := OfferSet{}
 	drivlyOffers.Source = "drivly"
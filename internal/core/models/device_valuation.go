@@ -1,9 +1,31 @@
 package models
 
+import (
+	"math"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/core/units"
+)
+
 type DeviceValuation struct {
 	// Contains a list of valuation sets, one for each vendor
 	ValuationSets []ValuationSet `json:"valuationSets"`
 }
+type ValuationHistory struct {
+	// Valuation sets over time, newest first
+	ValuationSets []ValuationSet `json:"valuationSets"`
+	// Pass as the cursor param to get the next page, empty if there are no more valuations
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// ValuationHistoryQuery filters and pages the valuation history. Zero From or To means no bound.
+type ValuationHistoryQuery struct {
+	From   time.Time
+	To     time.Time
+	Cursor string
+	Limit  int
+}
+
 type ValuationSet struct {
 	// The source of the valuation (eg. "drivly" or "blackbook")
 	Vendor string `json:"vendor"`
@@ -11,33 +33,65 @@ type ValuationSet struct {
 	Updated string `json:"updated,omitempty"`
 	// The mileage used for the valuation
 	Mileage int `json:"mileage,omitempty"`
+	// MileageSource telemetry when the mileage is the vehicle odometer, or how it was estimated: extrapolated or
+	// country_average. Empty for valuations from before it was recorded
+	MileageSource MileageSource `json:"mileageSource,omitempty"`
 	// This will be the zip code used (if any) for the valuation request regardless if the vendor uses it
 	ZipCode string `json:"zipCode,omitempty"`
-	// Useful when Drivly returns multiple vendors and we've selected one (eg. "drivly:blackbook")
+	// The vendor, for drivly with the books averaged into the price (eg. "drivly:blackbook,kbb")
 	TradeInSource string `json:"tradeInSource,omitempty"`
-	// tradeIn is equal to tradeInAverage when available
+	// tradeIn is the value in the vehicle's condition tier, equal to tradeInAverage when the condition is unknown
 	TradeIn int `json:"tradeIn,omitempty"`
 	// tradeInClean, tradeInAverage, and tradeInRough my not always be available
 	TradeInClean   int `json:"tradeInClean,omitempty"`
 	TradeInAverage int `json:"tradeInAverage,omitempty"`
 	TradeInRough   int `json:"tradeInRough,omitempty"`
-	// Useful when Drivly returns multiple vendors and we've selected one (eg. "drivly:blackbook")
+	// The vendor, for drivly with the books averaged into the price (eg. "drivly:blackbook,kbb")
 	RetailSource string `json:"retailSource,omitempty"`
-	// retail is equal to retailAverage when available
+	// retail is the value in the vehicle's condition tier, equal to retailAverage when the condition is unknown
 	Retail int `json:"retail,omitempty"`
 	// retailClean, retailAverage, and retailRough my not always be available
-	RetailClean   int    `json:"retailClean,omitempty"`
-	RetailAverage int    `json:"retailAverage,omitempty"`
-	RetailRough   int    `json:"retailRough,omitempty"`
-	OdometerUnit  string `json:"odometerUnit"`
+	RetailClean   int `json:"retailClean,omitempty"`
+	RetailAverage int `json:"retailAverage,omitempty"`
+	RetailRough   int `json:"retailRough,omitempty"`
+	// OdometerUnit km or miles, the unit of mileage and odometer
+	OdometerUnit string `json:"odometerUnit"`
 	// Odometer used for calculating values
 	Odometer int `json:"odometer"`
 	// whether estimated, real, or from the market (eg. vincario). Market, Estimated, Real
 	OdometerMeasurementType OdometerMeasurementEnum `json:"odometerMeasurementType"`
 	// UserDisplayPrice the top level value to show to users in mobile app
 	UserDisplayPrice int `json:"userDisplayPrice"`
+	// BlendingStrategy how userDisplayPrice was blended from the vendor prices, eg. vendor, mean or median. Empty for
+	// valuations from before strategies were recorded, which used vendor
+	BlendingStrategy string `json:"blendingStrategy,omitempty"`
 	// eg. USD or EUR
 	Currency string `json:"currency"`
+	// BatteryAdjustment amount userDisplayPrice was adjusted by for the EV battery health, negative for a degraded
+	// battery. The other prices are adjusted by the same percent, the book values in the sources are not
+	BatteryAdjustment int `json:"batteryAdjustment,omitempty"`
+	// BatteryStateOfHealth percent of the original EV battery capacity, when known
+	BatteryStateOfHealth float64 `json:"batteryStateOfHealth,omitempty"`
+	// ConditionTier clean, average or rough, scored from the vehicle's diagnostic signals. Selects the drivly tier used
+	// for tradeIn and retail
+	ConditionTier ConditionTier `json:"conditionTier,omitempty"`
+	// TradeInSources the book values drivly returned for trade-in, tradeIn is the average of those included
+	TradeInSources []ValuationSource `json:"tradeInSources,omitempty"`
+	// RetailSources the book values drivly returned for retail, retail is the average of those included
+	RetailSources []ValuationSource `json:"retailSources,omitempty"`
+}
+
+// ValuationSource a book value behind a blended price, eg. kbb or blackbook
+type ValuationSource struct {
+	// Source name of the book, eg. blackbook, kbb, edmunds, nada or cargurus
+	Source string `json:"source"`
+	// Value the book's value, used in the blended price
+	Value int `json:"value"`
+	// Clean and Rough condition tiers, when the book has them
+	Clean int `json:"clean,omitempty"`
+	Rough int `json:"rough,omitempty"`
+	// Included whether the value was used in the blended price, values of 100 or less are ignored
+	Included bool `json:"included"`
 }
 
 // OdometerMeasurementEnum is a custom type for representing different types of odometer measurements.
@@ -58,3 +112,18 @@ const (
 func (ome OdometerMeasurementEnum) String() string {
 	return string(ome)
 }
+
+// ConvertDistances converts mileage and odometer to the unit. Left as is when the set's unit is unknown
+func (v *ValuationSet) ConvertDistances(unit units.DistanceUnit) {
+	from, err := units.ParseDistanceUnit(v.OdometerUnit)
+	if err != nil {
+		return
+	}
+	v.Mileage = convertDistance(v.Mileage, from, unit)
+	v.Odometer = convertDistance(v.Odometer, from, unit)
+	v.OdometerUnit = unit.String()
+}
+
+func convertDistance(value int, from, to units.DistanceUnit) int {
+	return int(math.Round(units.Distance{Value: float64(value), Unit: from}.In(to).Value))
+}
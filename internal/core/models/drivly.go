@@ -17,5 +17,15 @@ type DrivlyVINSummary struct {
 
 type ValuationRequestData struct {
 	Mileage *float64 `json:"mileage,omitempty"`
-	ZipCode *string  `json:"zipCode,omitempty"`
+	// MileageSource whether the mileage is the vehicle odometer or an estimate, empty for requests from before it was recorded
+	MileageSource MileageSource `json:"mileageSource,omitempty"`
+	// OdometerExtrapolation how the mileage was extrapolated, set when MileageSource is extrapolated
+	OdometerExtrapolation *OdometerExtrapolation `json:"odometerExtrapolation,omitempty"`
+	ZipCode               *string                `json:"zipCode,omitempty"`
+	// CountryCode ISO 3166-1 alpha-2 country code of the vehicle location when the request was made
+	CountryCode *string `json:"countryCode,omitempty"`
+	// Condition scored from the diagnostic signals, nil if the vehicle reported none
+	Condition *ConditionAssessment `json:"condition,omitempty"`
+	// Battery health of an EV, nil for other powertrains or if it is unknown
+	Battery *BatteryHealth `json:"battery,omitempty"`
 }
@@ -26,6 +26,7 @@ type DeviceDefinition struct {
 
 type Vehicle struct {
 	ID         string `json:"id"`
+	TokenID    uint64 `json:"tokenId"`
 	Definition struct {
 		ID    string `json:"id"`
 		Make  string `json:"make"`
@@ -41,6 +42,41 @@ type SignalsLatest struct {
 	CurrentLocationLongitude                TimeFloatValue `json:"currentLocationLongitude"`
 }
 
+// ConditionSignals latest diagnostic signals used to score the vehicle condition. Signals the vehicle doesn't report
+// are left zero
+type ConditionSignals struct {
+	// OBDDTCList active diagnostic trouble codes, eg. ["P0300","P0420"]
+	OBDDTCList TimeStringValue `json:"obdDTCList"`
+	// PowertrainTractionBatteryStateOfHealth EV battery health, percent
+	PowertrainTractionBatteryStateOfHealth TimeFloatValue `json:"powertrainTractionBatteryStateOfHealth"`
+	// PowertrainTractionBatteryGrossCapacity EV battery capacity, kWh
+	PowertrainTractionBatteryGrossCapacity TimeFloatValue `json:"powertrainTractionBatteryGrossCapacity"`
+	// LowVoltageBatteryCurrentVoltage 12V battery, volts
+	LowVoltageBatteryCurrentVoltage TimeFloatValue `json:"lowVoltageBatteryCurrentVoltage"`
+	// tire pressures, kPa
+	ChassisAxleRow1WheelLeftTirePressure  TimeFloatValue `json:"chassisAxleRow1WheelLeftTirePressure"`
+	ChassisAxleRow1WheelRightTirePressure TimeFloatValue `json:"chassisAxleRow1WheelRightTirePressure"`
+	ChassisAxleRow2WheelLeftTirePressure  TimeFloatValue `json:"chassisAxleRow2WheelLeftTirePressure"`
+	ChassisAxleRow2WheelRightTirePressure TimeFloatValue `json:"chassisAxleRow2WheelRightTirePressure"`
+	// ServiceDistanceToService km until the next service is due, negative when overdue
+	ServiceDistanceToService TimeFloatValue `json:"serviceDistanceToService"`
+}
+
+type TimeStringValue struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     string    `json:"value"`
+}
+
+// VehicleTelemetry the telemetry-api data a valuation or offer is pulled with. It is resolved with the user's
+// privilege token when the pull is requested so the token is not queued with the job. Signals that could not be had,
+// eg. for revaluations that have no token, are left empty
+type VehicleTelemetry struct {
+	Signals          *SignalsLatest    `json:"signals,omitempty"`
+	ConditionSignals *ConditionSignals `json:"conditionSignals,omitempty"`
+	// OdometerHistory daily max odometer leading up to a stale odometer reading
+	OdometerHistory []TimeFloatValue `json:"odometerHistory,omitempty"`
+}
+
 type TimeFloatValue struct {
 	Timestamp time.Time `json:"timestamp"`
 	Value     float64   `json:"value"`
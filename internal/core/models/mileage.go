@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// MileageSource where the mileage a valuation was requested with came from
+type MileageSource string
+
+const (
+	// TelemetryMileage the odometer the vehicle reported
+	TelemetryMileage MileageSource = "telemetry"
+	// ExtrapolatedMileage extrapolated from the last odometer the vehicle reported, at the rate it has been driven
+	ExtrapolatedMileage MileageSource = "extrapolated"
+	// CountryAverageMileage the vehicle age times the average annual mileage in its country
+	CountryAverageMileage MileageSource = "country_average"
+)
+
+// String returns the string representation of the MileageSource.
+func (ms MileageSource) String() string {
+	return string(ms)
+}
+
+// OdometerMeasurementType real for an odometer the vehicle reported, estimated otherwise
+func (ms MileageSource) OdometerMeasurementType() OdometerMeasurementEnum {
+	if ms == TelemetryMileage {
+		return Real
+	}
+	return Estimated
+}
+
+// OdometerExtrapolation how an odometer reading was extrapolated to the time of the valuation request
+type OdometerExtrapolation struct {
+	// ReadingAt and ReadingMiles the odometer reading extrapolated from
+	ReadingAt    time.Time `json:"readingAt"`
+	ReadingMiles float64   `json:"readingMiles"`
+	// WindowFrom and WindowTo the period the driving rate was observed over. From the telemetry odometer history, or
+	// from the start of the model year for the lifetime rate
+	WindowFrom time.Time `json:"windowFrom"`
+	WindowTo   time.Time `json:"windowTo"`
+	// FromHistory whether the rate is from the telemetry odometer history rather than the lifetime rate
+	FromHistory bool    `json:"fromHistory"`
+	MilesPerDay float64 `json:"milesPerDay"`
+	// Confidence 0 to 1, lower the shorter the window and the further past it the odometer is extrapolated
+	Confidence float64 `json:"confidence"`
+}
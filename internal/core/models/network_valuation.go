@@ -0,0 +1,28 @@
+package models
+
+// NetworkValuation value of every vehicle on the network from the daily snapshot, in USD
+type NetworkValuation struct {
+	// SnapshotDate day the totals were taken, YYYY-MM-DD
+	SnapshotDate string  `json:"snapshotDate"`
+	TotalUSD     float64 `json:"totalUsd"`
+	VehicleCount int     `json:"vehicleCount"`
+	// WeekOverWeekGrowth percent change from the snapshot a week earlier, nil if there is none
+	WeekOverWeekGrowth *float64 `json:"weekOverWeekGrowth,omitempty"`
+	// MonthOverMonthGrowth percent change from the snapshot a month earlier, nil if there is none
+	MonthOverMonthGrowth *float64             `json:"monthOverMonthGrowth,omitempty"`
+	ByDefinition         []ValuationBreakdown `json:"byDefinition"`
+	ByCountry            []ValuationBreakdown `json:"byCountry"`
+}
+
+// ValuationBreakdown totals for a group of vehicles, by definition or country. Key is empty for vehicles we don't know
+// the definition or country of.
+type ValuationBreakdown struct {
+	// Key definition id, or alpha-3 country code
+	Key string `json:"key"`
+	// Make, Model and Year are parsed from the definition id, only set for definitions
+	Make         string  `json:"make,omitempty"`
+	Model        string  `json:"model,omitempty"`
+	Year         int     `json:"year,omitempty"`
+	TotalUSD     float64 `json:"totalUsd"`
+	VehicleCount int     `json:"vehicleCount"`
+}
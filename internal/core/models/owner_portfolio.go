@@ -0,0 +1,37 @@
+package models
+
+// OwnerPortfolio value of every vehicle owned by an address
+type OwnerPortfolio struct {
+	Owner string `json:"owner"`
+	// Currency all values are converted to, eg. USD
+	Currency string             `json:"currency"`
+	Vehicles []PortfolioVehicle `json:"vehicles"`
+	// TotalValue sum of the valued vehicles
+	TotalValue int `json:"totalValue"`
+	// ValuedCount vehicles with a valuation
+	ValuedCount int `json:"valuedCount"`
+	// UnvaluedCount vehicles we have never valued, or whose valuation could not be converted to the currency
+	UnvaluedCount int `json:"unvaluedCount"`
+	// EstimatedUnvaluedValue estimate for the unvalued vehicles, from the valued vehicles of the same definition when
+	// there are any, otherwise the average of all the valued vehicles
+	EstimatedUnvaluedValue int `json:"estimatedUnvaluedValue"`
+	// EstimatedTotalValue TotalValue plus EstimatedUnvaluedValue
+	EstimatedTotalValue int `json:"estimatedTotalValue"`
+}
+
+type PortfolioVehicle struct {
+	TokenID      uint64 `json:"tokenId"`
+	DefinitionID string `json:"definitionId"`
+	Make         string `json:"make"`
+	Model        string `json:"model"`
+	Year         int    `json:"year"`
+	Valued       bool   `json:"valued"`
+	// Value the user display price of the latest valuation, converted to the portfolio currency
+	Value int `json:"value,omitempty"`
+	// Vendor the source of the latest valuation
+	Vendor string `json:"vendor,omitempty"`
+	// Updated when the latest valuation was pulled
+	Updated string `json:"updated,omitempty"`
+	// Estimated true if the value is the fill-in estimate, not a valuation
+	Estimated bool `json:"estimated,omitempty"`
+}
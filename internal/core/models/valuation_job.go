@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type JobType string
+
+const (
+	ValuationJobType JobType = "valuation"
+	OfferJobType     JobType = "offer"
+)
+
+// ValuationJob is an asynchronous valuation or instant offer request, poll it to know when the pull is done
+type ValuationJob struct {
+	ID string `json:"id"`
+	// valuation or offer. Offer requests for countries without instant offers are queued as a valuation
+	Type    JobType `json:"type"`
+	TokenID uint64  `json:"tokenId"`
+	// Queued, Processing, or the pull result eg. PulledValuations, Skipped, Error
+	Status DataPullStatusEnum `json:"status"`
+	// Set when the status is Error
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ValuationJobRequest is the message published to the stream for the worker to process
+type ValuationJobRequest struct {
+	JobID       string `json:"jobId"`
+	TokenID     uint64 `json:"tokenId"`
+	Vin         string `json:"vin"`
+	CountryCode string `json:"countryCode"`
+	// Telemetry the vehicle signals some vendors need, resolved when the job is queued
+	Telemetry VehicleTelemetry `json:"telemetry"`
+}
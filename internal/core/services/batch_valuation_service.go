@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// BatchPullOptions options for a batch valuations pull
+type BatchPullOptions struct {
+	// WMIs only pull for VINs starting with one of these world manufacturer identifiers, all if empty
+	WMIs []string
+	// Concurrency max number of pulls running at the same time
+	Concurrency int
+	// DryRun only counts what would be pulled, no vendor calls are made
+	DryRun bool
+}
+
+// BatchPullSummary counts of what happened with each vehicle in a batch pull
+type BatchPullSummary struct {
+	Vehicles int
+	// Pulled new valuation stored, or would have been pulled in a dry run
+	Pulled int
+	// Skipped valuation still within the provider repull window, or the provider skipped it
+	Skipped int
+	// NoProvider the vehicle location is unknown or no provider is routed for the country
+	NoProvider int
+	Errored    int
+}
+
+// BatchValuationService pulls valuations for all the vehicles we have valued before, as they become due
+type BatchValuationService struct {
+	dbs         func() *db.ReaderWriter
+	log         *zerolog.Logger
+	locationSvc LocationService
+	providers   *ValuationProviderRegistry
+}
+
+func NewBatchValuationService(dbs func() *db.ReaderWriter, log *zerolog.Logger, locationSvc LocationService,
+	providers *ValuationProviderRegistry) *BatchValuationService {
+	return &BatchValuationService{
+		dbs:         dbs,
+		log:         log,
+		locationSvc: locationSvc,
+		providers:   providers,
+	}
+}
+
+type batchResult int
+
+const (
+	batchPulled batchResult = iota
+	batchSkipped
+	batchNoProvider
+	batchErrored
+)
+
+type batchVehicle struct {
+	tokenID uint64
+	vin     string
+}
+
+// PullValuations enumerates the vehicles in the valuations table, by token id with their latest VIN, and pulls a new
+// valuation for those where the preferred provider for their country is past its repull window.
+func (b *BatchValuationService) PullValuations(ctx context.Context, opts BatchPullOptions) (*BatchPullSummary, error) {
+	vehicles, err := b.vehicles(ctx, opts.WMIs)
+	if err != nil {
+		return nil, err
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	summary := &BatchPullSummary{Vehicles: len(vehicles)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, v := range vehicles {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(v batchVehicle) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			result := b.pullVehicle(ctx, v, opts.DryRun)
+			mu.Lock()
+			defer mu.Unlock()
+			switch result {
+			case batchPulled:
+				summary.Pulled++
+			case batchSkipped:
+				summary.Skipped++
+			case batchNoProvider:
+				summary.NoProvider++
+			case batchErrored:
+				summary.Errored++
+			}
+		}(v)
+	}
+	wg.Wait()
+
+	return summary, ctx.Err()
+}
+
+func (b *BatchValuationService) pullVehicle(ctx context.Context, v batchVehicle, dryRun bool) batchResult {
+	localLog := b.log.With().Uint64("token_id", v.tokenID).Str("vin", v.vin).Logger()
+
+	// batch has no privilege token, so can only use the location we already decoded for the vehicle
+	location, err := b.locationSvc.GetGeoDecodedLocation(ctx, nil, v.tokenID)
+	if err != nil {
+		localLog.Debug().Err(err).Msg("no location for vehicle, skipping")
+		return batchNoProvider
+	}
+	providers := b.providers.ValuationProvidersForCountry(location.CountryCode)
+	if len(providers) == 0 {
+		localLog.Debug().Msgf("no valuation provider for country %s, skipping", location.CountryCode)
+		return batchNoProvider
+	}
+	// providers are tried in order, so only the first one decides if the vehicle is due
+	due, err := b.isDue(ctx, providers[0], v.vin)
+	if err != nil {
+		localLog.Err(err).Msg("failed to check last valuation")
+		return batchErrored
+	}
+	if !due {
+		return batchSkipped
+	}
+	if dryRun {
+		localLog.Info().Msgf("dry run, would pull %s valuation", providers[0].Name())
+		return batchPulled
+	}
+
+	// no privilege token for telemetry, mileage is estimated
+	status, err := b.providers.PullValuation(ctx, location.CountryCode, v.tokenID, v.vin, core.VehicleTelemetry{})
+	if err != nil {
+		localLog.Err(err).Msg("failed to pull valuation")
+		return batchErrored
+	}
+	if status == core.SkippedDataPullStatus {
+		return batchSkipped
+	}
+	return batchPulled
+}
+
+// isDue true if the provider has no valuation for the VIN within its repull window
+func (b *BatchValuationService) isDue(ctx context.Context, provider ValuationProvider, vin string) (bool, error) {
+	latest, err := models.Valuations(
+		models.ValuationWhere.Vin.EQ(vin),
+		qm.Where(provider.MetadataColumn()+" is not null"),
+		qm.OrderBy("updated_at desc")).One(ctx, b.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+		return false, err
+	}
+	return latest.UpdatedAt.Add(provider.RepullWindow()).Before(time.Now()), nil
+}
+
+// vehicles every token id in the valuations table with its latest VIN, filtered by WMI
+func (b *BatchValuationService) vehicles(ctx context.Context, wmis []string) ([]batchVehicle, error) {
+	rows, err := models.Valuations(
+		qm.Select("distinct on (token_id) token_id, vin"),
+		qm.Where("token_id is not null"),
+		qm.OrderBy("token_id, created_at desc")).All(ctx, b.dbs().Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get vehicles from valuations")
+	}
+
+	vehicles := make([]batchVehicle, 0, len(rows))
+	for _, row := range rows {
+		if !matchesWMI(row.Vin, wmis) {
+			continue
+		}
+		tokenID, ok := row.TokenID.Uint64()
+		if !ok {
+			continue
+		}
+		vehicles = append(vehicles, batchVehicle{tokenID: tokenID, vin: row.Vin})
+	}
+	return vehicles, nil
+}
+
+// matchesWMI true if the VIN starts with any of the WMIs, or there are no WMIs to filter by
+func matchesWMI(vin string, wmis []string) bool {
+	if len(wmis) == 0 {
+		return true
+	}
+	for _, wmi := range wmis {
+		if strings.HasPrefix(strings.ToUpper(vin), strings.ToUpper(strings.TrimSpace(wmi))) {
+			return true
+		}
+	}
+	return false
+}
+
+// BackfillValuationColumns projects the vendor json of rows pulled before the valuation columns existed, or written by
+// an older valuationColumnsVersion, into them in batches of batchSize. Returns the number of rows updated, or that would
+// be in a dry run. Rows without a market value are left as they are and keep falling back to the json.
+func (b *BatchValuationService) BackfillValuationColumns(ctx context.Context, batchSize int, dryRun bool) (int, error) {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	updated := 0
+	lastID := ""
+	for {
+		rows, err := models.Valuations(
+			qm.Expr(
+				models.ValuationWhere.ColumnsVersion.IsNull(),
+				qm.Or2(models.ValuationWhere.ColumnsVersion.LT(null.IntFrom(valuationColumnsVersion)))),
+			models.ValuationWhere.ID.GT(lastID),
+			b.providers.HasValuationData(),
+			qm.OrderBy(models.ValuationColumns.ID),
+			qm.Limit(batchSize)).All(ctx, b.dbs().Reader)
+		if err != nil {
+			return updated, errors.Wrap(err, "failed to get valuations to backfill")
+		}
+		for _, row := range rows {
+			valSet := b.providers.projectVendorData(b.log, row, requestCountryCode(row))
+			if valSet == nil {
+				continue
+			}
+			updated++
+			if dryRun {
+				continue
+			}
+			setValuationColumns(row, valSet)
+			if _, err := row.Update(ctx, b.dbs().Writer, boil.Whitelist(valuationColumns...)); err != nil {
+				return updated, errors.Wrapf(err, "failed to backfill valuation %s", row.ID)
+			}
+		}
+		if len(rows) < batchSize {
+			return updated, nil
+		}
+		lastID = rows[len(rows)-1].ID
+		b.log.Info().Int("updated", updated).Msg("backfilled valuation columns batch")
+	}
+}
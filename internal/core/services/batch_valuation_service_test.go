@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	mock_services "github.com/DIMO-Network/valuations-api/internal/core/services/mocks"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/dbtest"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/mock/gomock"
+)
+
+type BatchValuationServiceTestSuite struct {
+	suite.Suite
+	pdb         db.Store
+	container   testcontainers.Container
+	ctx         context.Context
+	mockCtrl    *gomock.Controller
+	locationSvc *mock_services.MockLocationService
+	provider    *mock_services.MockValuationProvider
+	svc         *BatchValuationService
+}
+
+func (s *BatchValuationServiceTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container = dbtest.StartContainerDatabase(s.ctx, "valuations_api", s.T(), migrationsDirRelPath)
+}
+
+func (s *BatchValuationServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.locationSvc = mock_services.NewMockLocationService(s.mockCtrl)
+	s.provider = mock_services.NewMockValuationProvider(s.mockCtrl)
+	s.provider.EXPECT().Name().Return(DrivlyProvider).AnyTimes()
+	s.provider.EXPECT().SupportedCountries().Return([]string{"USA"}).AnyTimes()
+	s.provider.EXPECT().MetadataColumn().Return(models.ValuationColumns.DrivlyPricingMetadata).AnyTimes()
+	s.provider.EXPECT().RepullWindow().Return(time.Hour * 24 * 14).AnyTimes()
+	s.svc = NewBatchValuationService(s.pdb.DBS, dbtest.Logger(), s.locationSvc, NewValuationProviderRegistry(s.provider))
+}
+
+// TearDownTest after each test truncate tables
+func (s *BatchValuationServiceTestSuite) TearDownTest() {
+	dbtest.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *BatchValuationServiceTestSuite) TearDownSuite() {
+	fmt.Printf("shutting down postgres at with session: %s \n", s.container.SessionID())
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func TestBatchValuationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BatchValuationServiceTestSuite))
+}
+
+func (s *BatchValuationServiceTestSuite) TestPullValuations() {
+	// due, last valuation a month ago
+	due := setupCreateValuationsData(s.T(), 1, ksuid.New().String(), "1FMCU9J94NUA00001", map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+	}, &s.pdb)
+	due.UpdatedAt = time.Now().AddDate(0, -1, 0)
+	_, err := due.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.ValuationColumns.UpdatedAt))
+	require.NoError(s.T(), err)
+	// not due, just pulled
+	_ = setupCreateValuationsData(s.T(), 2, ksuid.New().String(), "1FMCU9J94NUA00002", map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+	}, &s.pdb)
+	// no location
+	_ = setupCreateValuationsData(s.T(), 3, ksuid.New().String(), "1FMCU9J94NUA00003", map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+	}, &s.pdb)
+	// filtered out by wmi
+	_ = setupCreateValuationsData(s.T(), 4, ksuid.New().String(), "WVWZZZ3CZWE000004", map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+	}, &s.pdb)
+
+	us := &core.LocationResponse{CountryCode: "US"}
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), nil, uint64(1)).Return(us, nil)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), nil, uint64(2)).Return(us, nil)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), nil, uint64(3)).Return(nil, fmt.Errorf("no signals provided"))
+	s.provider.EXPECT().PullValuation(gomock.Any(), uint64(1), "1FMCU9J94NUA00001", core.VehicleTelemetry{}).Return(core.PulledValuationDrivlyStatus, nil)
+
+	summary, err := s.svc.PullValuations(s.ctx, BatchPullOptions{WMIs: []string{"1FM"}, Concurrency: 2})
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), BatchPullSummary{Vehicles: 3, Pulled: 1, Skipped: 1, NoProvider: 1}, *summary)
+}
+
+func (s *BatchValuationServiceTestSuite) TestPullValuations_dryRun() {
+	_ = setupCreateValuationsData(s.T(), 5, ksuid.New().String(), "1FMCU9J94NUA00005", map[string][]byte{
+		"VincarioMetadata": []byte(testVincarioValuationJSON),
+	}, &s.pdb)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), nil, uint64(5)).Return(&core.LocationResponse{CountryCode: "US"}, nil)
+
+	// never pulled from drivly so is due, but no pull in a dry run
+	summary, err := s.svc.PullValuations(s.ctx, BatchPullOptions{DryRun: true})
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), BatchPullSummary{Vehicles: 1, Pulled: 1}, *summary)
+}
+
+func (s *BatchValuationServiceTestSuite) TestRevaluationScheduler_RunOnce() {
+	scheduler := NewRevaluationScheduler(s.pdb.DBS, dbtest.Logger(), s.svc, time.Hour, 1)
+
+	// another replica holds the lock
+	conn, err := s.pdb.DBS().Writer.Conn(s.ctx)
+	require.NoError(s.T(), err)
+	defer conn.Close() //nolint:errcheck
+	_, err = conn.ExecContext(s.ctx, "select pg_advisory_lock($1)", revaluationLockID)
+	require.NoError(s.T(), err)
+
+	ran, err := scheduler.RunOnce(s.ctx)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), ran)
+
+	_, err = conn.ExecContext(s.ctx, "select pg_advisory_unlock($1)", revaluationLockID)
+	require.NoError(s.T(), err)
+
+	ran, err = scheduler.RunOnce(s.ctx)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), ran)
+}
+
+func (s *BatchValuationServiceTestSuite) TestBackfillValuationColumns() {
+	row := setupCreateValuationsData(s.T(), 6, ksuid.New().String(), "1FMCU9J94NUA00006", map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+		"RequestMetadata":       []byte(`{"mileage": 49957, "zipCode": "48216", "countryCode": "CA"}`),
+	}, &s.pdb)
+	// blended with the strategy of the country the valuation was requested in
+	s.provider.EXPECT().ProjectValuation(gomock.Any(), "CA").
+		Return(&core.ValuationSet{Vendor: DrivlyProvider, TradeIn: 10000, Retail: 12000, UserDisplayPrice: 11000, BlendingStrategy: MedianBlending})
+
+	updated, err := s.svc.BackfillValuationColumns(s.ctx, 10, false)
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, updated)
+	require.NoError(s.T(), row.Reload(s.ctx, s.pdb.DBS().Reader))
+	assert.Equal(s.T(), 11000, row.UserDisplayPrice.Int)
+	assert.Equal(s.T(), MedianBlending, row.BlendingStrategy.String)
+	assert.Equal(s.T(), valuationColumnsVersion, row.ColumnsVersion.Int)
+
+	updated, err = s.svc.BackfillValuationColumns(s.ctx, 10, false)
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), updated, "rows at the current version are not projected again")
+}
+
+func Test_matchesWMI(t *testing.T) {
+	assert.True(t, matchesWMI("1FMCU9J94NUA00001", nil))
+	assert.True(t, matchesWMI("1FMCU9J94NUA00001", []string{"WVW", " 1fm"}))
+	assert.False(t, matchesWMI("1FMCU9J94NUA00001", []string{"WVW"}))
+}
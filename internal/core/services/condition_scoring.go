@@ -0,0 +1,137 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+)
+
+// conditionSignalMaxAge signals older than this no longer reflect the vehicle condition and are ignored
+const conditionSignalMaxAge = 30 * 24 * time.Hour
+
+// score thresholds for the condition tiers
+const (
+	cleanConditionScore   = 90
+	averageConditionScore = 70
+)
+
+// tire pressures outside this range, in kPa, count as a problem
+const (
+	minTirePressure = 180.0
+	maxTirePressure = 350.0
+)
+
+// ScoreCondition scores the vehicle condition out of 100 from its diagnostic signals and maps it to a condition tier.
+// Returns nil if there are no recent signals to score from, the condition is unknown.
+func ScoreCondition(signals *core.ConditionSignals, now time.Time) *core.ConditionAssessment {
+	if signals == nil {
+		return nil
+	}
+	fresh := func(ts time.Time) bool {
+		return !ts.IsZero() && now.Sub(ts) <= conditionSignalMaxAge
+	}
+	scored := false
+	assessment := &core.ConditionAssessment{Score: 100}
+	deduct := func(points int, format string, args ...interface{}) {
+		assessment.Score -= points
+		assessment.Deductions = append(assessment.Deductions, fmt.Sprintf(format, args...))
+	}
+
+	if fresh(signals.OBDDTCList.Timestamp) {
+		scored = true
+		if codes := parseDTCList(signals.OBDDTCList.Value); len(codes) > 0 {
+			deduct(min(len(codes)*10, 40), "%d diagnostic trouble codes: %s", len(codes), strings.Join(codes, ","))
+		}
+	}
+	if soh := signals.PowertrainTractionBatteryStateOfHealth; fresh(soh.Timestamp) && soh.Value > 0 {
+		scored = true
+		switch {
+		case soh.Value < 70:
+			deduct(30, "traction battery health %.0f%%", soh.Value)
+		case soh.Value < 80:
+			deduct(20, "traction battery health %.0f%%", soh.Value)
+		case soh.Value < 90:
+			deduct(10, "traction battery health %.0f%%", soh.Value)
+		}
+	}
+	if voltage := signals.LowVoltageBatteryCurrentVoltage; fresh(voltage.Timestamp) && voltage.Value > 0 {
+		scored = true
+		if voltage.Value < 11.8 {
+			deduct(10, "12V battery at %.1fV", voltage.Value)
+		}
+	}
+	for name, pressure := range map[string]core.TimeFloatValue{
+		"front left":  signals.ChassisAxleRow1WheelLeftTirePressure,
+		"front right": signals.ChassisAxleRow1WheelRightTirePressure,
+		"rear left":   signals.ChassisAxleRow2WheelLeftTirePressure,
+		"rear right":  signals.ChassisAxleRow2WheelRightTirePressure,
+	} {
+		if !fresh(pressure.Timestamp) || pressure.Value <= 0 {
+			continue
+		}
+		scored = true
+		if pressure.Value < minTirePressure || pressure.Value > maxTirePressure {
+			deduct(5, "%s tire pressure %.0fkPa", name, pressure.Value)
+		}
+	}
+	if service := signals.ServiceDistanceToService; fresh(service.Timestamp) {
+		scored = true
+		if service.Value < 0 {
+			deduct(15, "service overdue by %.0fkm", -service.Value)
+		}
+	}
+	if !scored {
+		return nil
+	}
+
+	// map iteration order, keep the deductions stable
+	sort.Strings(assessment.Deductions)
+	switch {
+	case assessment.Score >= cleanConditionScore:
+		assessment.Tier = core.CleanCondition
+	case assessment.Score >= averageConditionScore:
+		assessment.Tier = core.AverageCondition
+	default:
+		assessment.Tier = core.RoughCondition
+	}
+	return assessment
+}
+
+// parseDTCList the trouble codes from the obdDTCList signal, a json array or a comma separated list
+func parseDTCList(value string) []string {
+	var codes []string
+	if err := json.Unmarshal([]byte(value), &codes); err == nil {
+		return codes
+	}
+	return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+}
+
+// conditionValue the book's value in the condition tier, its headline value if the book doesn't price that tier
+func conditionValue(source core.ValuationSource, tier core.ConditionTier) int {
+	switch {
+	case tier == core.CleanCondition && source.Clean > minDrivlyBookValue:
+		return source.Clean
+	case tier == core.RoughCondition && source.Rough > minDrivlyBookValue:
+		return source.Rough
+	}
+	return source.Value
+}
+
+// conditionPrice average of the included books' values in the condition tier, 0 if no book was included
+func conditionPrice(sources []core.ValuationSource, tier core.ConditionTier) int {
+	sum, count := 0, 0
+	for _, s := range sources {
+		if s.Included {
+			sum += conditionValue(s, tier)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / count
+}
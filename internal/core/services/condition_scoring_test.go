@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_ScoreCondition(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		signals *core.ConditionSignals
+		tier    core.ConditionTier
+		score   int
+	}{
+		{
+			name: "no problems",
+			signals: &core.ConditionSignals{
+				OBDDTCList:                           core.TimeStringValue{Timestamp: recent, Value: "[]"},
+				LowVoltageBatteryCurrentVoltage:      core.TimeFloatValue{Timestamp: recent, Value: 12.6},
+				ChassisAxleRow1WheelLeftTirePressure: core.TimeFloatValue{Timestamp: recent, Value: 240},
+			},
+			tier:  core.CleanCondition,
+			score: 100,
+		},
+		{
+			name: "trouble code and overdue service",
+			signals: &core.ConditionSignals{
+				OBDDTCList:               core.TimeStringValue{Timestamp: recent, Value: `["P0420"]`},
+				ServiceDistanceToService: core.TimeFloatValue{Timestamp: recent, Value: -500},
+			},
+			tier:  core.AverageCondition,
+			score: 100 - 10 - 15,
+		},
+		{
+			name: "worn ev",
+			signals: &core.ConditionSignals{
+				OBDDTCList:                             core.TimeStringValue{Timestamp: recent, Value: "P0A80, P0AFA, U0100, P0300, P0420"},
+				PowertrainTractionBatteryStateOfHealth: core.TimeFloatValue{Timestamp: recent, Value: 68},
+				ChassisAxleRow2WheelRightTirePressure:  core.TimeFloatValue{Timestamp: recent, Value: 120},
+			},
+			tier:  core.RoughCondition,
+			score: 100 - 40 - 30 - 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assessment := ScoreCondition(tt.signals, now)
+
+			require.NotNil(t, assessment)
+			assert.Equal(t, tt.tier, assessment.Tier)
+			assert.Equal(t, tt.score, assessment.Score)
+		})
+	}
+}
+
+func Test_ScoreCondition_unknown(t *testing.T) {
+	now := time.Now()
+
+	assert.Nil(t, ScoreCondition(nil, now))
+	assert.Nil(t, ScoreCondition(&core.ConditionSignals{}, now))
+	assert.Nil(t, ScoreCondition(&core.ConditionSignals{
+		OBDDTCList: core.TimeStringValue{Timestamp: now.AddDate(0, -2, 0), Value: `["P0300"]`},
+	}, now), "stale signals are ignored")
+}
+
+func Test_drivlyValuationService_ProjectValuation_conditionTier(t *testing.T) {
+	project := func(requestJSON string) *core.ValuationSet {
+		return (&drivlyValuationService{}).ProjectValuation(&models.Valuation{
+			DrivlyPricingMetadata: null.JSONFrom([]byte(testDrivlyValuations3JSON)),
+			RequestMetadata:       null.JSONFrom([]byte(requestJSON)),
+		}, "")
+	}
+
+	unknown := project(`{"mileage": 50000}`)
+	require.NotNil(t, unknown)
+	assert.Equal(t, core.AverageCondition, unknown.ConditionTier)
+	assert.Equal(t, unknown.TradeInAverage, unknown.TradeIn)
+
+	clean := project(`{"mileage": 50000, "condition": {"tier": "clean", "score": 95}}`)
+	require.NotNil(t, clean)
+	assert.Equal(t, core.CleanCondition, clean.ConditionTier)
+	assert.Equal(t, unknown.TradeInAverage, clean.TradeInAverage)
+	assert.Greater(t, clean.TradeIn, clean.TradeInAverage)
+	assert.Greater(t, clean.UserDisplayPrice, unknown.UserDisplayPrice)
+
+	rough := project(`{"mileage": 50000, "condition": {"tier": "rough", "score": 40}}`)
+	require.NotNil(t, rough)
+	assert.Less(t, rough.TradeIn, rough.TradeInAverage)
+	assert.Less(t, rough.Retail, rough.RetailAverage)
+}
@@ -1,44 +1,43 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/url"
 	"time"
 
 	core "github.com/DIMO-Network/valuations-api/internal/core/models"
 
 	"github.com/DIMO-Network/shared/pkg/db"
-	"github.com/DIMO-Network/shared/pkg/http"
 	"github.com/DIMO-Network/valuations-api/internal/config"
 	"github.com/pkg/errors"
 )
 
 //go:generate mockgen -source drivly_api_service.go -destination mocks/drivly_api_service_mock.go
 type DrivlyAPIService interface {
-	GetVINInfo(vin string) (map[string]interface{}, error)
-	GetVINPricing(vin string, reqData *core.ValuationRequestData) (map[string]any, error)
-
-	GetOffersByVIN(vin string, reqData *core.ValuationRequestData) (map[string]interface{}, error)
-	GetAutocheckByVIN(vin string) (map[string]interface{}, error)
-	GetBuildByVIN(vin string) (map[string]interface{}, error)
-	GetCargurusByVIN(vin string) (map[string]interface{}, error)
-	GetCarvanaByVIN(vin string) (map[string]interface{}, error)
-	GetCarmaxByVIN(vin string) (map[string]interface{}, error)
-	GetCarstoryByVIN(vin string) (map[string]interface{}, error)
-	GetEdmundsByVIN(vin string) (map[string]interface{}, error)
-	GetTMVByVIN(vin string) (map[string]interface{}, error)
-	GetKBBByVIN(vin string) (map[string]interface{}, error)
-	GetVRoomByVIN(vin string) (map[string]interface{}, error)
-
-	GetExtendedOffersByVIN(vin string) (*core.DrivlyVINSummary, error)
+	GetVINInfo(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetVINPricing(ctx context.Context, vin string, reqData *core.ValuationRequestData) (map[string]any, error)
+
+	GetOffersByVIN(ctx context.Context, vin string, reqData *core.ValuationRequestData) (map[string]interface{}, error)
+	GetAutocheckByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetBuildByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetCargurusByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetCarvanaByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetCarmaxByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetCarstoryByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetEdmundsByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetTMVByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetKBBByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+	GetVRoomByVIN(ctx context.Context, vin string) (map[string]interface{}, error)
+
+	GetExtendedOffersByVIN(ctx context.Context, vin string) (*core.DrivlyVINSummary, error)
 }
 
 type drivlyAPIService struct {
 	settings        *config.Settings
-	httpClientVIN   http.ClientWrapper
-	httpClientOffer http.ClientWrapper
+	httpClientVIN   *vendorClient
+	httpClientOffer *vendorClient
 	dbs             func() *db.ReaderWriter
 }
 
@@ -46,21 +45,19 @@ func NewDrivlyAPIService(settings *config.Settings, dbs func() *db.ReaderWriter)
 	if settings.DrivlyVINAPIURL == "" || settings.DrivlyAPIKey == "" || settings.DrivlyOfferAPIURL == "" {
 		panic("Drivly configuration not set")
 	}
-	h := map[string]string{"x-api-key": settings.DrivlyAPIKey}
-	hcwv, _ := http.NewClientWrapper(settings.DrivlyVINAPIURL, "", 120*time.Second, h, true)
-	hcwo, _ := http.NewClientWrapper(settings.DrivlyOfferAPIURL, "", 240*time.Second, h, true)
-
+	h := map[string]string{"x-api-key": settings.DrivlyAPIKey, "Accept": "application/json"}
+	// one breaker per host
 	return &drivlyAPIService{
 		settings:        settings,
-		httpClientVIN:   hcwv,
-		httpClientOffer: hcwo,
+		httpClientVIN:   newVendorClient(DrivlyProvider, settings.DrivlyVINAPIURL, 120*time.Second, h, drivlyRetryPolicy),
+		httpClientOffer: newVendorClient(DrivlyProvider, settings.DrivlyOfferAPIURL, 240*time.Second, h, drivlyOfferRetryPolicy),
 		dbs:             dbs,
 	}
 }
 
 // GetVINInfo is the basic enriched VIN call, that is pretty standard now. Looks in multiple sources in their backend.
-func (ds *drivlyAPIService) GetVINInfo(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientVIN, fmt.Sprintf("/api/%s/", vin))
+func (ds *drivlyAPIService) GetVINInfo(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientVIN, fmt.Sprintf("/api/%s/", vin))
 
 	if err != nil {
 		return nil, err
@@ -70,7 +67,7 @@ func (ds *drivlyAPIService) GetVINInfo(vin string) (map[string]interface{}, erro
 }
 
 // GetVINPricing mileage is not sent if nil and zipcode is not sent if length is not equal to 5
-func (ds *drivlyAPIService) GetVINPricing(vin string, reqData *core.ValuationRequestData) (map[string]any, error) {
+func (ds *drivlyAPIService) GetVINPricing(ctx context.Context, vin string, reqData *core.ValuationRequestData) (map[string]any, error) {
 	params := url.Values{}
 	if reqData.Mileage != nil && *reqData.Mileage < 400000 {
 		params.Add("mileage", fmt.Sprint(int(*reqData.Mileage)))
@@ -78,7 +75,7 @@ func (ds *drivlyAPIService) GetVINPricing(vin string, reqData *core.ValuationReq
 	if reqData.ZipCode != nil && len(*reqData.ZipCode) == 5 { // US 5 digit zip codes only
 		params.Add("zipcode", *reqData.ZipCode)
 	}
-	res, err := executeAPI(ds.httpClientVIN, fmt.Sprintf("/api/%s/Pricing?"+params.Encode(), vin))
+	res, err := executeAPI(ctx, ds.httpClientVIN, fmt.Sprintf("/api/%s/Pricing?"+params.Encode(), vin))
 
 	if err != nil {
 		return nil, err
@@ -87,14 +84,14 @@ func (ds *drivlyAPIService) GetVINPricing(vin string, reqData *core.ValuationReq
 	_, tok := res["trade"]
 	_, rok := res["retail"]
 	if !tok && !rok {
-		return nil, fmt.Errorf("no valid drivly pricing found for vin: %s", vin)
+		return nil, errors.Wrapf(ErrVINNotFound, "no valid drivly pricing found for vin: %s", vin)
 	}
 
 	return res, nil
 }
 
 // GetOffersByVIN mileage is not sent if nil and zipcode is not sent if length is not equal to 5
-func (ds *drivlyAPIService) GetOffersByVIN(vin string, reqData *core.ValuationRequestData) (map[string]interface{}, error) {
+func (ds *drivlyAPIService) GetOffersByVIN(ctx context.Context, vin string, reqData *core.ValuationRequestData) (map[string]interface{}, error) {
 	params := url.Values{}
 	if reqData.Mileage != nil && *reqData.Mileage < 400000 {
 		params.Add("mileage", fmt.Sprint(int(*reqData.Mileage)))
@@ -102,7 +99,7 @@ func (ds *drivlyAPIService) GetOffersByVIN(vin string, reqData *core.ValuationRe
 	if reqData.ZipCode != nil && len(*reqData.ZipCode) == 5 { // US 5 digit zip codes only
 		params.Add("zipcode", *reqData.ZipCode)
 	}
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s?"+params.Encode(), vin))
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s?"+params.Encode(), vin))
 
 	if err != nil {
 		return nil, err
@@ -111,8 +108,8 @@ func (ds *drivlyAPIService) GetOffersByVIN(vin string, reqData *core.ValuationRe
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetAutocheckByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/autocheck", vin))
+func (ds *drivlyAPIService) GetAutocheckByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/autocheck", vin))
 
 	if err != nil {
 		return nil, err
@@ -121,8 +118,8 @@ func (ds *drivlyAPIService) GetAutocheckByVIN(vin string) (map[string]interface{
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetBuildByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/build", vin))
+func (ds *drivlyAPIService) GetBuildByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/build", vin))
 
 	if err != nil {
 		return nil, err
@@ -131,8 +128,8 @@ func (ds *drivlyAPIService) GetBuildByVIN(vin string) (map[string]interface{}, e
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetCargurusByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/cargurus", vin))
+func (ds *drivlyAPIService) GetCargurusByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/cargurus", vin))
 
 	if err != nil {
 		return nil, err
@@ -141,8 +138,8 @@ func (ds *drivlyAPIService) GetCargurusByVIN(vin string) (map[string]interface{}
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetCarmaxByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/carmax", vin))
+func (ds *drivlyAPIService) GetCarmaxByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/carmax", vin))
 
 	if err != nil {
 		return nil, err
@@ -151,8 +148,8 @@ func (ds *drivlyAPIService) GetCarmaxByVIN(vin string) (map[string]interface{},
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetCarstoryByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/carstory", vin))
+func (ds *drivlyAPIService) GetCarstoryByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/carstory", vin))
 
 	if err != nil {
 		return nil, err
@@ -161,8 +158,8 @@ func (ds *drivlyAPIService) GetCarstoryByVIN(vin string) (map[string]interface{}
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetCarvanaByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/carvana", vin))
+func (ds *drivlyAPIService) GetCarvanaByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/carvana", vin))
 
 	if err != nil {
 		return nil, err
@@ -172,8 +169,8 @@ func (ds *drivlyAPIService) GetCarvanaByVIN(vin string) (map[string]interface{},
 }
 
 // GetEdmundsByVIN one of their raw data sources, the style_id they return may or not may be perfect.
-func (ds *drivlyAPIService) GetEdmundsByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/edmunds", vin))
+func (ds *drivlyAPIService) GetEdmundsByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/edmunds", vin))
 
 	if err != nil {
 		return nil, err
@@ -182,8 +179,8 @@ func (ds *drivlyAPIService) GetEdmundsByVIN(vin string) (map[string]interface{},
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetTMVByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/tmv", vin))
+func (ds *drivlyAPIService) GetTMVByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/tmv", vin))
 
 	if err != nil {
 		return nil, err
@@ -192,8 +189,8 @@ func (ds *drivlyAPIService) GetTMVByVIN(vin string) (map[string]interface{}, err
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetKBBByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/kbb", vin))
+func (ds *drivlyAPIService) GetKBBByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/kbb", vin))
 
 	if err != nil {
 		return nil, err
@@ -202,8 +199,8 @@ func (ds *drivlyAPIService) GetKBBByVIN(vin string) (map[string]interface{}, err
 	return res, nil
 }
 
-func (ds *drivlyAPIService) GetVRoomByVIN(vin string) (map[string]interface{}, error) {
-	res, err := executeAPI(ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/tmv", vin))
+func (ds *drivlyAPIService) GetVRoomByVIN(ctx context.Context, vin string) (map[string]interface{}, error) {
+	res, err := executeAPI(ctx, ds.httpClientOffer, fmt.Sprintf("/api/vin/%s/tmv", vin))
 
 	if err != nil {
 		return nil, err
@@ -213,65 +210,65 @@ func (ds *drivlyAPIService) GetVRoomByVIN(vin string) (map[string]interface{}, e
 }
 
 // GetExtendedOffersByVIN calls all apis for offers and build info except the VIN info endpoint
-func (ds *drivlyAPIService) GetExtendedOffersByVIN(vin string) (*core.DrivlyVINSummary, error) {
+func (ds *drivlyAPIService) GetExtendedOffersByVIN(ctx context.Context, vin string) (*core.DrivlyVINSummary, error) {
 	result := new(core.DrivlyVINSummary)
 
-	pricingRes, err := ds.GetVINPricing(vin, nil)
+	pricingRes, err := ds.GetVINPricing(ctx, vin, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	offerRes, err := ds.GetOffersByVIN(vin, nil)
+	offerRes, err := ds.GetOffersByVIN(ctx, vin, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	autoCheckRes, err := ds.GetAutocheckByVIN(vin)
+	autoCheckRes, err := ds.GetAutocheckByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	buildRes, err := ds.GetBuildByVIN(vin)
+	buildRes, err := ds.GetBuildByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	cargurusRes, err := ds.GetCargurusByVIN(vin)
+	cargurusRes, err := ds.GetCargurusByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	carmaxRes, err := ds.GetCarmaxByVIN(vin)
+	carmaxRes, err := ds.GetCarmaxByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	carstoryRes, err := ds.GetCarstoryByVIN(vin)
+	carstoryRes, err := ds.GetCarstoryByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	carvanaRes, err := ds.GetCarvanaByVIN(vin)
+	carvanaRes, err := ds.GetCarvanaByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	edmundsRes, err := ds.GetEdmundsByVIN(vin)
+	edmundsRes, err := ds.GetEdmundsByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	tmvRes, err := ds.GetTMVByVIN(vin)
+	tmvRes, err := ds.GetTMVByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	kbbRes, err := ds.GetKBBByVIN(vin)
+	kbbRes, err := ds.GetKBBByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
 
-	vroomRes, err := ds.GetVRoomByVIN(vin)
+	vroomRes, err := ds.GetVRoomByVIN(ctx, vin)
 	if err != nil {
 		return nil, err
 	}
@@ -292,23 +289,13 @@ func (ds *drivlyAPIService) GetExtendedOffersByVIN(vin string) (*core.DrivlyVINS
 	return result, nil
 }
 
-func executeAPI(httpClient http.ClientWrapper, path string) (map[string]interface{}, error) {
-	res, err := httpClient.ExecuteRequest(path, "GET", nil)
-	if res == nil {
-		if err != nil {
-			return nil, errors.Wrapf(err, "error calling driv.ly api => %s", path)
-		}
-		return nil, fmt.Errorf("received error with no response when calling GET to %s", path)
-	}
-
-	if err != nil && res.StatusCode != 404 {
-		return nil, errors.Wrapf(err, "error calling driv.ly api => %s", path)
+func executeAPI(ctx context.Context, client *vendorClient, path string) (map[string]interface{}, error) {
+	body, err := client.Get(ctx, path)
+	if err != nil {
+		return nil, err
 	}
-	defer res.Body.Close()
 
-	body, _ := io.ReadAll(res.Body)
 	var result map[string]interface{}
-
 	err = json.Unmarshal(body, &result)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error parsing driv.ly api data => %s", path)
@@ -2,21 +2,25 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
 
 	"github.com/ericlagergren/decimal"
+	"github.com/tidwall/gjson"
 	"github.com/volatiletech/sqlboiler/v4/types"
 
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DIMO-Network/shared/pkg/db"
 	"github.com/DIMO-Network/valuations-api/internal/config"
 	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/core/units"
 	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
 
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/segmentio/ksuid"
 	"github.com/volatiletech/null/v8"
@@ -27,39 +31,63 @@ import (
 //go:generate mockgen -source drivly_valuation_service.go -destination mocks/drivly_valuation_service_mock.go
 
 type DrivlyValuationService interface {
-	PullValuation(ctx context.Context, tokenID uint64, vin, privJWTAuthHeader string) (core.DataPullStatusEnum, error)
-	PullOffer(ctx context.Context, tokenID uint64, vin, privJWTAuthHeader string) (core.DataPullStatusEnum, error)
+	ValuationProvider
+	OfferProvider
 }
 
 type drivlyValuationService struct {
 	dbs          func() *db.ReaderWriter
 	drivlySvc    DrivlyAPIService
 	identityAPI  gateways.IdentityAPI
-	telemetryAPI gateways.TelemetryAPI
 	log          *zerolog.Logger
 	locationSvc  LocationService
+	blending     *PriceBlending
+	batteryCurve *BatteryAdjustmentCurve
 }
 
-func NewDrivlyValuationService(DBS func() *db.ReaderWriter, log *zerolog.Logger, settings *config.Settings) DrivlyValuationService {
+func NewDrivlyValuationService(DBS func() *db.ReaderWriter, log *zerolog.Logger, settings *config.Settings, identityAPI gateways.IdentityAPI,
+	locationSvc LocationService, blending *PriceBlending, batteryCurve *BatteryAdjustmentCurve) DrivlyValuationService {
 	return &drivlyValuationService{
 		dbs:          DBS,
 		log:          log,
 		drivlySvc:    NewDrivlyAPIService(settings, DBS),
-		identityAPI:  gateways.NewIdentityAPIService(log, settings),
-		telemetryAPI: gateways.NewTelemetryAPI(log, settings),
-		locationSvc:  NewLocationService(DBS, settings, log),
+		identityAPI:  identityAPI,
+		locationSvc:  locationSvc,
+		blending:     blending,
+		batteryCurve: batteryCurve,
 	}
 }
 
+func (d *drivlyValuationService) Name() string {
+	return DrivlyProvider
+}
+
+func (d *drivlyValuationService) MetadataColumn() string {
+	return models.ValuationColumns.DrivlyPricingMetadata
+}
+
+// SupportedCountries drivly valuations are USA only, unless routed otherwise
+func (d *drivlyValuationService) SupportedCountries() []string {
+	return []string{"USA"}
+}
+
+// OfferCountries drivly instant offers are available in north america
+func (d *drivlyValuationService) OfferCountries() []string {
+	return strings.Split(NorthAmercanCountries, ",")
+}
+
+func (d *drivlyValuationService) RepullWindow() time.Duration {
+	return time.Hour * 24 * 14
+}
+
 // PullValuation performs a data pull for a vehicle valuation. It retrieves pricing and
 // other relevant data for a given VIN. Not necessary for the userDevice to exist, VIN is what matters
-func (d *drivlyValuationService) PullValuation(ctx context.Context, tokenID uint64, vin, privJWTAuthHeader string) (core.DataPullStatusEnum, error) {
-	const repullWindow = time.Hour * 24 * 14
+func (d *drivlyValuationService) PullValuation(ctx context.Context, tokenID uint64, vin string, telemetry core.VehicleTelemetry) (core.DataPullStatusEnum, error) {
 	if len(vin) != 17 {
 		return core.ErrorDataPullStatus, fmt.Errorf("invalid VIN %s", vin)
 	}
 
-	vehicle, err := d.identityAPI.GetVehicle(tokenID)
+	vehicle, err := d.identityAPI.GetVehicle(ctx, tokenID)
 	if err != nil {
 		return core.ErrorDataPullStatus, err
 	}
@@ -70,9 +98,9 @@ func (d *drivlyValuationService) PullValuation(ctx context.Context, tokenID uint
 		models.ValuationWhere.Vin.EQ(vin),
 		models.ValuationWhere.DrivlyPricingMetadata.IsNotNull(),
 		qm.OrderBy("updated_at desc"), qm.Limit(1)).
-		One(context.Background(), d.dbs().Writer)
+		One(ctx, d.dbs().Writer)
 	// just return if already pulled recently for this VIN, but still need to insert never pulled vin - should be uncommon scenario
-	if existingPricingData != nil && existingPricingData.UpdatedAt.Add(repullWindow).After(time.Now()) {
+	if existingPricingData != nil && existingPricingData.UpdatedAt.Add(d.RepullWindow()).After(time.Now()) {
 		localLog.Info().Msgf("already pulled pricing data for vin %s, skipping", vin)
 		return core.SkippedDataPullStatus, nil
 	}
@@ -86,18 +114,18 @@ func (d *drivlyValuationService) PullValuation(ctx context.Context, tokenID uint
 	}
 
 	// get mileage for the drivly request
-	signals, err := d.telemetryAPI.GetLatestSignals(tokenID, privJWTAuthHeader)
-	if err != nil {
-		d.log.Warn().Err(err).Uint64("token_id", tokenID).Msgf("could not get telemetry latest signals for token %d", tokenID)
+	signals := telemetry.Signals
+	reqData := core.ValuationRequestData{}
+	// condition picks the drivly tier, valued as average without it
+	conditionSignals := telemetry.ConditionSignals
+	if conditionSignals == nil {
+		localLog.Warn().Msg("no telemetry condition signals, valuing as average condition")
 	}
-
-	deviceMileage := getDeviceMileage(signals, vehicle.Definition.Year, time.Now().Year())
-	if deviceMileage == 0 {
-		localLog.Warn().Msg("vehicle mileage found was 0 for valuation pull request")
-	}
-
-	reqData := core.ValuationRequestData{
-		Mileage: &deviceMileage,
+	reqData.Condition = ScoreCondition(conditionSignals, time.Now())
+	if def, err := d.identityAPI.GetDefinition(ctx, vehicle.Definition.ID); err != nil {
+		localLog.Warn().Err(err).Msg("could not get device definition, skipping the EV battery adjustment")
+	} else {
+		reqData.Battery = d.batteryCurve.BatteryHealth(def, conditionSignals, time.Now())
 	}
 	location, err := d.locationSvc.GetGeoDecodedLocation(ctx, signals, tokenID)
 	if err != nil {
@@ -105,17 +133,31 @@ func (d *drivlyValuationService) PullValuation(ctx context.Context, tokenID uint
 		return core.SkippedDataPullStatus, fmt.Errorf("unable to get vehicle location to provide valuation")
 	} else {
 		reqData.ZipCode = &location.PostalCode
+		reqData.CountryCode = &location.CountryCode
+	}
+
+	lastReading, err := lastMileageReading(ctx, d.dbs, tokenID)
+	if err != nil {
+		localLog.Warn().Err(err).Msg("could not get last mileage reading, estimating without it")
 	}
-	if location.CountryCode != "US" {
-		return core.SkippedDataPullStatus, fmt.Errorf("valuations only available for USA")
+	mileage := estimateMileage(signals, telemetry.OdometerHistory, lastReading, vehicle.Definition.Year, location.CountryCode, time.Now())
+	if mileage.Miles == 0 {
+		localLog.Warn().Msg("vehicle mileage found was 0 for valuation pull request")
 	}
+	reqData.Mileage = &mileage.Miles
+	reqData.MileageSource = mileage.Source
+	reqData.OdometerExtrapolation = mileage.Extrapolation
 
 	// add the request data to the valuation record
 	_ = valuation.RequestMetadata.Marshal(reqData)
 	// cal drivly for pricing
-	pricing, err := d.drivlySvc.GetVINPricing(vin, &reqData)
-	if err == nil {
-		_ = valuation.DrivlyPricingMetadata.Marshal(pricing)
+	pricing, err := d.drivlySvc.GetVINPricing(ctx, vin, &reqData)
+	if err != nil {
+		return core.ErrorDataPullStatus, errors.Wrap(err, "error pulling drivly pricing")
+	}
+	_ = valuation.DrivlyPricingMetadata.Marshal(pricing)
+	if valSet := d.ProjectValuation(valuation, *reqData.CountryCode); valSet != nil {
+		setValuationColumns(valuation, valSet)
 	}
 
 	err = valuation.Insert(ctx, d.dbs().Writer, boil.Infer())
@@ -128,9 +170,9 @@ func (d *drivlyValuationService) PullValuation(ctx context.Context, tokenID uint
 	return core.PulledValuationDrivlyStatus, nil
 }
 
-func (d *drivlyValuationService) PullOffer(ctx context.Context, tokenID uint64, vin, privJWTAuthHeader string) (core.DataPullStatusEnum, error) {
+func (d *drivlyValuationService) PullOffer(ctx context.Context, tokenID uint64, vin string, telemetry core.VehicleTelemetry) (core.DataPullStatusEnum, error) {
 	// make sure userdevice exists
-	vehicle, err := d.identityAPI.GetVehicle(tokenID)
+	vehicle, err := d.identityAPI.GetVehicle(ctx, tokenID)
 	if err != nil {
 		return core.ErrorDataPullStatus, err
 	}
@@ -153,32 +195,37 @@ func (d *drivlyValuationService) PullOffer(ctx context.Context, tokenID uint64,
 		}
 	}
 	// future: pull by tokenID from identity-api
-	deviceDef, err := d.identityAPI.GetDefinition(vehicle.Definition.ID)
+	deviceDef, err := d.identityAPI.GetDefinition(ctx, vehicle.Definition.ID)
 	if err != nil {
 		return core.ErrorDataPullStatus, err
 	}
 
 	// get mileage for the drivly request
-	signals, err := d.telemetryAPI.GetLatestSignals(tokenID, privJWTAuthHeader)
-	if err != nil {
+	signals := telemetry.Signals
+	if signals == nil {
 		// just warn if can't get data
-		localLog.Warn().Err(err).Msgf("could not find any telemtry data to obtain mileage or location - continuing without")
-	}
-	deviceMileage := getDeviceMileage(signals, deviceDef.Year, time.Now().Year())
-
-	if deviceMileage == 0 {
-		localLog.Warn().Msg("vehicle mileage found was 0")
-	}
-
-	params := core.ValuationRequestData{
-		Mileage: &deviceMileage,
+		localLog.Warn().Msgf("could not find any telemtry data to obtain mileage or location - continuing without")
 	}
+	params := core.ValuationRequestData{}
+	countryCode := ""
 	gloc, _ := models.GeodecodedLocations(models.GeodecodedLocationWhere.TokenID.EQ(int64(tokenID))).One(ctx, d.dbs().Reader)
 	if gloc != nil {
 		params.ZipCode = &gloc.PostalCode.String
+		countryCode = gloc.Country.String
 	}
+	lastReading, err := lastMileageReading(ctx, d.dbs, tokenID)
+	if err != nil {
+		localLog.Warn().Err(err).Msg("could not get last mileage reading, estimating without it")
+	}
+	mileage := estimateMileage(signals, telemetry.OdometerHistory, lastReading, deviceDef.Year, countryCode, time.Now())
+	if mileage.Miles == 0 {
+		localLog.Warn().Msg("vehicle mileage found was 0")
+	}
+	params.Mileage = &mileage.Miles
+	params.MileageSource = mileage.Source
+	params.OdometerExtrapolation = mileage.Extrapolation
 
-	offer, err := d.drivlySvc.GetOffersByVIN(vin, &params)
+	offer, err := d.drivlySvc.GetOffersByVIN(ctx, vin, &params)
 
 	if err != nil {
 		localLog.Err(err).Msg("error pulling drivly offer data")
@@ -192,10 +239,7 @@ func (d *drivlyValuationService) PullOffer(ctx context.Context, tokenID uint64,
 		Vin:                vin,
 		TokenID:            types.NewNullDecimal(decimal.New(int64(tokenID), 0)),
 	}
-	pj, err := json.Marshal(params)
-	if err == nil {
-		newOffer.OfferMetadata = null.JSONFrom(pj)
-	}
+	_ = newOffer.RequestMetadata.Marshal(params)
 	_ = newOffer.OfferMetadata.Marshal(offer)
 
 	err = newOffer.Insert(ctx, d.dbs().Writer, boil.Infer())
@@ -206,29 +250,187 @@ func (d *drivlyValuationService) PullOffer(ctx context.Context, tokenID uint64,
 	return core.PulledValuationDrivlyStatus, nil
 }
 
-const EstMilesPerYear = 12000.0
+// ProjectValuation builds the valuation set from the drivly pricing response. Trade-in and retail are averaged across
+// the books drivly returns, in the condition tier scored when the valuation was requested, and the display price is
+// blended from them with the country's strategy.
+func (d *drivlyValuationService) ProjectValuation(valuation *models.Valuation, countryCode string) *core.ValuationSet {
+	if !valuation.DrivlyPricingMetadata.Valid {
+		return nil
+	}
+	valSet := core.ValuationSet{
+		Updated: valuation.UpdatedAt.Format(time.RFC3339),
+		Vendor:  DrivlyProvider,
+	}
 
-func getDeviceMileage(signals *core.SignalsLatest, modelYear int, currentYear int) (mileage float64) {
-	if signals != nil {
-		odoKm := signals.PowertrainTransmissionTravelledDistance.Value
-		if odoKm > 0 {
-			return odoKm * 0.621271
-		} // convert to miles
+	drivlyJSON := valuation.DrivlyPricingMetadata.JSON
+	requestJSON := valuation.RequestMetadata.JSON
+	drivlyMileage := gjson.GetBytes(drivlyJSON, "mileage")
+	if drivlyMileage.Exists() {
+		valSet.Mileage = int(drivlyMileage.Int())
+		valSet.Odometer = int(drivlyMileage.Int())
+	} else {
+		// drivly didn't echo the mileage, it is the one requested
+		requestMileage := gjson.GetBytes(requestJSON, "mileage")
+		if requestMileage.Exists() {
+			valSet.Mileage = int(requestMileage.Int())
+			valSet.Odometer = int(requestMileage.Int())
+		}
+	}
+	// drivly is always requested in miles
+	valSet.OdometerUnit = units.Miles.String()
+	requestZipCode := gjson.GetBytes(requestJSON, "zipCode")
+	if requestZipCode.Exists() {
+		valSet.ZipCode = requestZipCode.String()
+	}
+	clean := func(s core.ValuationSource) int { return s.Clean }
+	rough := func(s core.ValuationSource) int { return s.Rough }
+	// price in the condition tier scored at request time, average when the condition is unknown
+	tier := core.ConditionTier(gjson.GetBytes(requestJSON, "condition.tier").String())
+	if tier == "" {
+		tier = core.AverageCondition
+	}
+	valSet.ConditionTier = tier
+	// Drivly Trade-In
+	valSet.TradeInAverage, valSet.TradeInSources = extractDrivlyValuation(drivlyJSON, "trade")
+	valSet.TradeInSource = drivlySourceName(valSet.TradeInSources)
+	valSet.TradeInClean = averageTier(valSet.TradeInSources, clean)
+	valSet.TradeInRough = averageTier(valSet.TradeInSources, rough)
+	valSet.TradeIn = valSet.TradeInAverage
+	if len(valSet.TradeInSources) > 0 {
+		valSet.TradeIn = conditionPrice(valSet.TradeInSources, tier)
+	}
+	// Drivly Retail
+	valSet.RetailAverage, valSet.RetailSources = extractDrivlyValuation(drivlyJSON, "retail")
+	valSet.RetailSource = drivlySourceName(valSet.RetailSources)
+	valSet.RetailClean = averageTier(valSet.RetailSources, clean)
+	valSet.RetailRough = averageTier(valSet.RetailSources, rough)
+	valSet.Retail = valSet.RetailAverage
+	if len(valSet.RetailSources) > 0 {
+		valSet.Retail = conditionPrice(valSet.RetailSources, tier)
+	}
+	valSet.Currency = "USD"
+
+	// set the price to display to users, blended from the books, or the mid-point of trade-in and retail
+	blend := BlendInput{VendorPrice: float64(valSet.Retail+valSet.TradeIn) / 2}
+	for _, src := range append(valSet.TradeInSources, valSet.RetailSources...) {
+		if src.Included {
+			blend.Points = append(blend.Points, PricePoint{Source: src.Source, Value: float64(conditionValue(src, tier))})
+		}
 	}
-	// if get here means need to just estimate
-	deviceMileage := float64(0)
-	yearDiff := currentYear - modelYear
+	if len(valSet.TradeInSources) == 0 && len(valSet.RetailSources) == 0 {
+		for source, price := range map[string]int{"trade": valSet.TradeIn, "retail": valSet.Retail} {
+			if price > 0 {
+				blend.Points = append(blend.Points, PricePoint{Source: source, Value: float64(price)})
+			}
+		}
+	}
+	strategy := d.blending.ForCountry(countryCode)
+	valSet.UserDisplayPrice = int(strategy.Blend(blend))
+	valSet.BlendingStrategy = strategy.Name()
+	applyBatteryAdjustment(&valSet, requestJSON)
+	// set odo type from where the mileage came from
+	valSet.MileageSource = core.MileageSource(gjson.GetBytes(requestJSON, "mileageSource").String())
 	switch {
-	case yearDiff > 0:
-		// Past model year
-		deviceMileage = float64(yearDiff) * EstMilesPerYear
-	case yearDiff == 0:
-		// Current model year
-		deviceMileage = EstMilesPerYear / 2
+	case valSet.MileageSource != "":
+		valSet.OdometerMeasurementType = valSet.MileageSource.OdometerMeasurementType()
+	case valSet.Odometer%12000 == 0:
+		// requests from before the source was recorded were estimated at 12000 miles a year
+		valSet.OdometerMeasurementType = core.Estimated
 	default:
-		// Next model year
-		deviceMileage = 0
+		valSet.OdometerMeasurementType = core.Real
 	}
 
-	return deviceMileage
+	return &valSet
+}
+
+// drivlyBook where a book's values are in the drivly trade or retail json. The first value path that exists is used
+type drivlyBook struct {
+	source string
+	value  []string
+	clean  string
+	rough  string
+}
+
+// drivlyBooks the books drivly returns, in the order they are reported
+var drivlyBooks = []drivlyBook{
+	{source: "blackbook", value: []string{"blackBook.totalAvg"}, clean: "blackBook.totalClean", rough: "blackBook.totalRough"},
+	{source: "kbb", value: []string{"kelley.good", "kelley.book"}},
+	{source: "edmunds", value: []string{"edmunds.average"}, clean: "edmunds.clean", rough: "edmunds.rough"},
+	{source: "nada", value: []string{"nada.book"}, rough: "nada.roughBook"},
+	{source: "cargurus", value: []string{"cargurus"}},
+}
+
+// minDrivlyBookValue book values at or below this are placeholders and left out of the blended price
+const minDrivlyBookValue = 100
+
+// extractDrivlyValuation pulls out the price from the drivly json, based on the passed in key, eg. trade or retail.
+// If there is no root value, it is the average of the book values drivly returns, which are returned as the sources.
+func extractDrivlyValuation(drivlyJSON []byte, key string) (int, []core.ValuationSource) {
+	// handle when value is just set at top level
+	if gjson.GetBytes(drivlyJSON, key).Exists() && !gjson.GetBytes(drivlyJSON, key).IsObject() {
+		v := gjson.GetBytes(drivlyJSON, key).String()
+		vf, _ := strconv.ParseFloat(v, 64)
+		return int(vf), nil
+	}
+	// if no specific value, make an average of all values drivly offers
+	var sources []core.ValuationSource
+	sum, count := 0, 0
+	for _, book := range drivlyBooks {
+		var value gjson.Result
+		for _, path := range book.value {
+			if value = gjson.GetBytes(drivlyJSON, key+"."+path); value.Exists() {
+				break
+			}
+		}
+		if !value.Exists() {
+			continue
+		}
+		source := core.ValuationSource{Source: book.source, Value: int(value.Int())}
+		if book.clean != "" {
+			source.Clean = int(gjson.GetBytes(drivlyJSON, key+"."+book.clean).Int())
+		}
+		if book.rough != "" {
+			source.Rough = int(gjson.GetBytes(drivlyJSON, key+"."+book.rough).Int())
+		}
+		if source.Value > minDrivlyBookValue {
+			source.Included = true
+			sum += source.Value
+			count++
+		}
+		sources = append(sources, source)
+	}
+	if count == 0 {
+		return 0, sources
+	}
+	return sum / count, sources
+}
+
+// averageTier average of the included sources' clean or rough value, 0 if none of them have the tier
+func averageTier(sources []core.ValuationSource, tier func(core.ValuationSource) int) int {
+	sum, count := 0, 0
+	for _, s := range sources {
+		if s.Included && tier(s) > minDrivlyBookValue {
+			sum += tier(s)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / count
+}
+
+// drivlySourceName the vendor with the books that were averaged, eg. drivly:blackbook,kbb. Just the vendor if drivly
+// returned a single value
+func drivlySourceName(sources []core.ValuationSource) string {
+	var included []string
+	for _, s := range sources {
+		if s.Included {
+			included = append(included, s.Source)
+		}
+	}
+	if len(included) == 0 {
+		return DrivlyProvider
+	}
+	return DrivlyProvider + ":" + strings.Join(included, ",")
 }
@@ -3,11 +3,71 @@ package services
 import (
 	"testing"
 
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
 )
 
-func Test_drivlyValuationService_getDeviceMileage_nil_udd(t *testing.T) {
-	mileage := getDeviceMileage(nil, 2020, 2023)
+func Test_extractDrivlyValuation_sources(t *testing.T) {
+	tradeIn, sources := extractDrivlyValuation([]byte(testDrivlyPricingJSON), "trade")
 
-	assert.Equal(t, 36000.0, mileage)
+	assert.Equal(t, 50396, tradeIn)
+	assert.Equal(t, []core.ValuationSource{
+		{Source: "blackbook", Value: 49040, Clean: 51330, Rough: 43650, Included: true},
+		{Source: "kbb", Value: 52173, Included: true},
+		{Source: "edmunds", Value: 49241, Clean: 50918, Rough: 47061, Included: true},
+		{Source: "nada", Value: 52825, Rough: 48200, Included: true},
+		{Source: "cargurus", Value: 48704, Included: true},
+	}, sources)
+}
+
+func Test_extractDrivlyValuation_placeholderValues(t *testing.T) {
+	tradeIn, sources := extractDrivlyValuation([]byte(`{"trade": {"kelley": {"book": 20000}, "cargurus": 0}}`), "trade")
+
+	assert.Equal(t, 20000, tradeIn)
+	require.Len(t, sources, 2)
+	assert.False(t, sources[1].Included, "cargurus of 0 is left out of the average")
+	assert.Equal(t, "drivly:kbb", drivlySourceName(sources))
+
+	tradeIn, sources = extractDrivlyValuation([]byte(`{"trade": {"cargurus": 50}}`), "trade")
+	assert.Equal(t, 0, tradeIn, "no book over the minimum")
+	assert.Equal(t, DrivlyProvider, drivlySourceName(sources))
+}
+
+func Test_drivlyValuationService_ProjectValuation_tiers(t *testing.T) {
+	valSet := (&drivlyValuationService{}).ProjectValuation(&models.Valuation{
+		DrivlyPricingMetadata: null.JSONFrom([]byte(testDrivlyPricingJSON)),
+	}, "")
+	require.NotNil(t, valSet)
+
+	assert.Equal(t, "drivly:blackbook,kbb,edmunds,nada,cargurus", valSet.TradeInSource)
+	assert.Equal(t, 51124, valSet.TradeInClean)
+	assert.Equal(t, 46303, valSet.TradeInRough)
+	// retail is a single top level value, no books behind it
+	assert.Equal(t, 54123, valSet.Retail)
+	assert.Equal(t, DrivlyProvider, valSet.RetailSource)
+	assert.Empty(t, valSet.RetailSources)
+	assert.Zero(t, valSet.RetailClean)
+}
+
+func Test_drivlyValuationService_ProjectValuation_mileageSource(t *testing.T) {
+	project := func(requestJSON string) *core.ValuationSet {
+		return (&drivlyValuationService{}).ProjectValuation(&models.Valuation{
+			DrivlyPricingMetadata: null.JSONFrom([]byte(`{"trade": 20000, "retail": 24000, "mileage": 48000}`)),
+			RequestMetadata:       null.JSONFrom([]byte(requestJSON)),
+		}, "")
+	}
+
+	reported := project(`{"mileage": 48000, "mileageSource": "telemetry"}`)
+	assert.Equal(t, core.Real, reported.OdometerMeasurementType, "a real odometer that is a multiple of 12000")
+	assert.Equal(t, core.TelemetryMileage, reported.MileageSource)
+
+	estimated := project(`{"mileage": 48000, "mileageSource": "country_average"}`)
+	assert.Equal(t, core.Estimated, estimated.OdometerMeasurementType)
+
+	legacy := project(`{"mileage": 48000}`)
+	assert.Equal(t, core.Estimated, legacy.OdometerMeasurementType)
+	assert.Empty(t, legacy.MileageSource)
 }
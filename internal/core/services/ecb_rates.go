@@ -0,0 +1,97 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/pkg/errors"
+)
+
+// ParseECBCSV parses the ECB euro foreign exchange reference rates csv, eurofxref.csv or eurofxref-hist.csv: a Date
+// column followed by one column per currency, with N/A for currencies not published that day.
+func ParseECBCSV(r io.Reader) ([]*models.FxRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read ecb csv")
+	}
+	if len(records) < 2 {
+		return nil, errors.New("ecb csv has no rates")
+	}
+	header := records[0]
+	if !strings.EqualFold(strings.TrimSpace(header[0]), "Date") {
+		return nil, errors.Errorf("ecb csv first column should be Date, got %s", header[0])
+	}
+
+	var rates []*models.FxRate
+	for _, record := range records[1:] {
+		rateDate, err := parseECBDate(record[0])
+		if err != nil {
+			return nil, err
+		}
+		for i := 1; i < len(record) && i < len(header); i++ {
+			currency := strings.TrimSpace(header[i])
+			value := strings.TrimSpace(record[i])
+			if currency == "" || value == "" || value == "N/A" {
+				continue
+			}
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s rate on %s", currency, record[0])
+			}
+			rates = append(rates, &models.FxRate{Currency: currency, RateDate: rateDate, Rate: rate})
+		}
+	}
+	return rates, nil
+}
+
+type ecbEnvelope struct {
+	Cubes []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string  `xml:"currency,attr"`
+			Rate     float64 `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// ParseECBXML parses the ECB euro foreign exchange reference rates xml, eurofxref-daily.xml or eurofxref-hist.xml
+func ParseECBXML(r io.Reader) ([]*models.FxRate, error) {
+	envelope := ecbEnvelope{}
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, errors.Wrap(err, "failed to read ecb xml")
+	}
+
+	var rates []*models.FxRate
+	for _, cube := range envelope.Cubes {
+		rateDate, err := parseECBDate(cube.Time)
+		if err != nil {
+			return nil, err
+		}
+		for _, rate := range cube.Rates {
+			rates = append(rates, &models.FxRate{Currency: rate.Currency, RateDate: rateDate, Rate: rate.Rate})
+		}
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("ecb xml has no rates")
+	}
+	return rates, nil
+}
+
+func parseECBDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	// the csv downloads use 02 January 2006, the history csv uses 2006-01-02
+	for _, layout := range []string{time.DateOnly, "02 January 2006"} {
+		if d, err := time.Parse(layout, s); err == nil {
+			return d, nil
+		}
+	}
+	return time.Time{}, errors.Errorf("invalid ecb rate date %s", s)
+}
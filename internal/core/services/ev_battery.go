@@ -0,0 +1,136 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/pkg/errors"
+	"github.com/tidwall/gjson"
+)
+
+// DefaultEVBatteryAdjustmentCurve used when EV_BATTERY_ADJUSTMENT_CURVE is empty. A battery at 90% health takes 4% off
+// the price, at 60% or less 40%.
+const DefaultEVBatteryAdjustmentCurve = "100:0,90:-4,80:-12,70:-25,60:-40"
+
+// device definition attributes used to detect an EV and its original battery capacity
+const (
+	powertrainTypeAttribute  = "powertrain_type"
+	batteryCapacityAttribute = "battery_capacity_kwh"
+	electricPowertrain       = "BEV"
+)
+
+type curvePoint struct {
+	stateOfHealth float64
+	percent       float64
+}
+
+// BatteryAdjustmentCurve maps EV battery state of health to a price adjustment percent, interpolating linearly between
+// points and flat past the first and last point
+type BatteryAdjustmentCurve struct {
+	points []curvePoint
+}
+
+// NewBatteryAdjustmentCurve parses EV_BATTERY_ADJUSTMENT_CURVE, eg. 100:0,90:-4,80:-12
+func NewBatteryAdjustmentCurve(settings *config.Settings) (*BatteryAdjustmentCurve, error) {
+	curve := settings.EVBatteryAdjustmentCurve
+	if strings.TrimSpace(curve) == "" {
+		curve = DefaultEVBatteryAdjustmentCurve
+	}
+	c := &BatteryAdjustmentCurve{}
+	for _, point := range strings.Split(curve, ",") {
+		soh, percent, found := strings.Cut(point, ":")
+		if !found {
+			return nil, errors.Errorf("invalid EV_BATTERY_ADJUSTMENT_CURVE point %q, expected health:percent", point)
+		}
+		sohF, err := strconv.ParseFloat(strings.TrimSpace(soh), 64)
+		if err != nil || sohF < 0 || sohF > 100 {
+			return nil, errors.Errorf("invalid EV_BATTERY_ADJUSTMENT_CURVE point %q, health must be 0-100", point)
+		}
+		percentF, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+		if err != nil || percentF <= -100 {
+			return nil, errors.Errorf("invalid EV_BATTERY_ADJUSTMENT_CURVE point %q, percent must be more than -100", point)
+		}
+		c.points = append(c.points, curvePoint{stateOfHealth: sohF, percent: percentF})
+	}
+	sort.Slice(c.points, func(i, j int) bool {
+		return c.points[i].stateOfHealth < c.points[j].stateOfHealth
+	})
+	return c, nil
+}
+
+// AdjustmentPercent price adjustment for the state of health, 0 on a nil curve
+func (c *BatteryAdjustmentCurve) AdjustmentPercent(stateOfHealth float64) float64 {
+	if c == nil || len(c.points) == 0 {
+		return 0
+	}
+	if stateOfHealth <= c.points[0].stateOfHealth {
+		return c.points[0].percent
+	}
+	for i := 1; i < len(c.points); i++ {
+		lo, hi := c.points[i-1], c.points[i]
+		if stateOfHealth <= hi.stateOfHealth {
+			return lo.percent + (stateOfHealth-lo.stateOfHealth)/(hi.stateOfHealth-lo.stateOfHealth)*(hi.percent-lo.percent)
+		}
+	}
+	return c.points[len(c.points)-1].percent
+}
+
+// BatteryHealth state of health of an EV's traction battery and the adjustment for it, from the state of health signal,
+// or the gross capacity signal against the definition's original capacity. Nil if the vehicle is not an EV or the
+// health is unknown.
+func (c *BatteryAdjustmentCurve) BatteryHealth(def *core.DeviceDefinition, signals *core.ConditionSignals, now time.Time) *core.BatteryHealth {
+	if def == nil || signals == nil || !strings.EqualFold(definitionAttribute(def, powertrainTypeAttribute), electricPowertrain) {
+		return nil
+	}
+	fresh := func(ts time.Time) bool {
+		return !ts.IsZero() && now.Sub(ts) <= conditionSignalMaxAge
+	}
+	soh := 0.0
+	if signal := signals.PowertrainTractionBatteryStateOfHealth; fresh(signal.Timestamp) && signal.Value > 0 {
+		soh = signal.Value
+	} else if signal := signals.PowertrainTractionBatteryGrossCapacity; fresh(signal.Timestamp) && signal.Value > 0 {
+		original, err := strconv.ParseFloat(definitionAttribute(def, batteryCapacityAttribute), 64)
+		if err != nil || original <= 0 {
+			return nil
+		}
+		soh = math.Min(signal.Value/original*100, 100)
+	}
+	if soh == 0 {
+		return nil
+	}
+	return &core.BatteryHealth{StateOfHealth: soh, AdjustmentPercent: c.AdjustmentPercent(soh)}
+}
+
+func definitionAttribute(def *core.DeviceDefinition, name string) string {
+	for _, attr := range def.Attributes {
+		if attr.Name == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// applyBatteryAdjustment adjusts the prices by the battery health recorded in the request, the book values in the
+// sources are left as the books gave them
+func applyBatteryAdjustment(valSet *core.ValuationSet, requestJSON []byte) {
+	battery := gjson.GetBytes(requestJSON, "battery")
+	if !battery.Exists() {
+		return
+	}
+	valSet.BatteryStateOfHealth = battery.Get("stateOfHealth").Float()
+	factor := 1 + battery.Get("adjustmentPercent").Float()/100
+	displayPrice := valSet.UserDisplayPrice
+	for _, price := range []*int{
+		&valSet.TradeIn, &valSet.TradeInClean, &valSet.TradeInAverage, &valSet.TradeInRough,
+		&valSet.Retail, &valSet.RetailClean, &valSet.RetailAverage, &valSet.RetailRough,
+		&valSet.UserDisplayPrice,
+	} {
+		*price = int(math.Round(float64(*price) * factor))
+	}
+	valSet.BatteryAdjustment = valSet.UserDisplayPrice - displayPrice
+}
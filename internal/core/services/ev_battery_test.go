@@ -0,0 +1,78 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_BatteryAdjustmentCurve(t *testing.T) {
+	curve, err := NewBatteryAdjustmentCurve(&config.Settings{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.0, curve.AdjustmentPercent(100))
+	assert.Equal(t, -4.0, curve.AdjustmentPercent(90))
+	assert.InDelta(t, -8.0, curve.AdjustmentPercent(85), 0.001, "interpolated between 90 and 80")
+	assert.Equal(t, -40.0, curve.AdjustmentPercent(40), "flat below the last point")
+
+	_, err = NewBatteryAdjustmentCurve(&config.Settings{EVBatteryAdjustmentCurve: "100:0,90"})
+	assert.Error(t, err)
+	_, err = NewBatteryAdjustmentCurve(&config.Settings{EVBatteryAdjustmentCurve: "100:0,50:-100"})
+	assert.Error(t, err)
+}
+
+func Test_BatteryAdjustmentCurve_BatteryHealth(t *testing.T) {
+	curve, err := NewBatteryAdjustmentCurve(&config.Settings{})
+	require.NoError(t, err)
+	now := time.Now()
+	ev := &core.DeviceDefinition{}
+	require.NoError(t, json.Unmarshal([]byte(`{"attributes": [
+		{"name": "powertrain_type", "value": "BEV"},
+		{"name": "battery_capacity_kwh", "value": "75"}
+	]}`), ev))
+
+	health := curve.BatteryHealth(ev, &core.ConditionSignals{
+		PowertrainTractionBatteryStateOfHealth: core.TimeFloatValue{Timestamp: now, Value: 80},
+	}, now)
+	require.NotNil(t, health)
+	assert.Equal(t, core.BatteryHealth{StateOfHealth: 80, AdjustmentPercent: -12}, *health)
+
+	health = curve.BatteryHealth(ev, &core.ConditionSignals{
+		PowertrainTractionBatteryGrossCapacity: core.TimeFloatValue{Timestamp: now, Value: 67.5},
+	}, now)
+	require.NotNil(t, health, "health from the capacity against the definition's")
+	assert.InDelta(t, 90, health.StateOfHealth, 0.001)
+
+	assert.Nil(t, curve.BatteryHealth(&core.DeviceDefinition{}, &core.ConditionSignals{
+		PowertrainTractionBatteryStateOfHealth: core.TimeFloatValue{Timestamp: now, Value: 80},
+	}, now), "not an EV")
+	assert.Nil(t, curve.BatteryHealth(ev, &core.ConditionSignals{}, now), "health unknown")
+}
+
+func Test_drivlyValuationService_ProjectValuation_batteryAdjustment(t *testing.T) {
+	project := func(requestJSON string) *core.ValuationSet {
+		return (&drivlyValuationService{}).ProjectValuation(&models.Valuation{
+			DrivlyPricingMetadata: null.JSONFrom([]byte(testDrivlyPricingJSON)),
+			RequestMetadata:       null.JSONFrom([]byte(requestJSON)),
+		}, "")
+	}
+
+	ice := project(`{"mileage": 50000}`)
+	ev := project(`{"mileage": 50000, "battery": {"stateOfHealth": 80, "adjustmentPercent": -12}}`)
+	require.NotNil(t, ice)
+	require.NotNil(t, ev)
+
+	assert.Zero(t, ice.BatteryAdjustment)
+	assert.Equal(t, 80.0, ev.BatteryStateOfHealth)
+	assert.Equal(t, ev.UserDisplayPrice-ice.UserDisplayPrice, ev.BatteryAdjustment)
+	assert.InDelta(t, float64(ice.UserDisplayPrice)*0.88, ev.UserDisplayPrice, 1)
+	assert.InDelta(t, float64(ice.Retail)*0.88, ev.Retail, 1)
+	assert.Equal(t, ice.TradeInSources, ev.TradeInSources, "book values are left as the books gave them")
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+//go:generate mockgen -source fx_rate_service.go -destination mocks/fx_rate_service_mock.go
+
+// FxRateService converts amounts between currencies using the reference rates in the fx_rates table
+type FxRateService interface {
+	// Convert converts the amount at the rate effective on the date, the latest rate published on or before it
+	Convert(ctx context.Context, amount float64, from, to string, on time.Time) (float64, error)
+	// ConvertValuationSet converts all the prices in the valuation set to the currency, at the rate effective on the date
+	ConvertValuationSet(ctx context.Context, valSet *core.ValuationSet, to string, on time.Time) error
+	// SaveRates inserts or updates the rates
+	SaveRates(ctx context.Context, rates []*models.FxRate) error
+}
+
+// BaseCurrency rates are stored as units of currency per 1 EUR, as published by the ECB
+const BaseCurrency = "EUR"
+
+// ErrNoFxRate there is no rate for the currency on or before the date
+var ErrNoFxRate = errors.New("no exchange rate")
+
+// fxRateCacheTTL rates for past dates don't change, but a rate for today may still be loaded
+const fxRateCacheTTL = time.Hour
+
+type fxRateService struct {
+	dbs   func() *db.ReaderWriter
+	mu    sync.Mutex
+	cache map[string]cachedFxRate
+}
+
+type cachedFxRate struct {
+	rate    float64
+	expires time.Time
+}
+
+func NewFxRateService(dbs func() *db.ReaderWriter) FxRateService {
+	return &fxRateService{
+		dbs:   dbs,
+		cache: map[string]cachedFxRate{},
+	}
+}
+
+func (f *fxRateService) Convert(ctx context.Context, amount float64, from, to string, on time.Time) (float64, error) {
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	if from == to {
+		return amount, nil
+	}
+	fromRate, err := f.rate(ctx, from, on)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := f.rate(ctx, to, on)
+	if err != nil {
+		return 0, err
+	}
+	return amount / fromRate * toRate, nil
+}
+
+func (f *fxRateService) ConvertValuationSet(ctx context.Context, valSet *core.ValuationSet, to string, on time.Time) error {
+	if valSet.Currency == "" || strings.EqualFold(valSet.Currency, to) {
+		return nil
+	}
+	factor, err := f.Convert(ctx, 1, valSet.Currency, to, on)
+	if err != nil {
+		return err
+	}
+	for _, price := range []*int{
+		&valSet.TradeIn, &valSet.TradeInClean, &valSet.TradeInAverage, &valSet.TradeInRough,
+		&valSet.Retail, &valSet.RetailClean, &valSet.RetailAverage, &valSet.RetailRough,
+		&valSet.UserDisplayPrice, &valSet.BatteryAdjustment,
+	} {
+		*price = int(math.Round(float64(*price) * factor))
+	}
+	for _, sources := range [][]core.ValuationSource{valSet.TradeInSources, valSet.RetailSources} {
+		for i := range sources {
+			sources[i].Value = int(math.Round(float64(sources[i].Value) * factor))
+			sources[i].Clean = int(math.Round(float64(sources[i].Clean) * factor))
+			sources[i].Rough = int(math.Round(float64(sources[i].Rough) * factor))
+		}
+	}
+	valSet.Currency = strings.ToUpper(to)
+	return nil
+}
+
+func (f *fxRateService) SaveRates(ctx context.Context, rates []*models.FxRate) error {
+	tx, err := f.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, rate := range rates {
+		err := rate.Upsert(ctx, tx, true, []string{models.FxRateColumns.Currency, models.FxRateColumns.RateDate},
+			boil.Whitelist(models.FxRateColumns.Rate), boil.Infer())
+		if err != nil {
+			return errors.Wrapf(err, "failed to save %s rate for %s", rate.Currency, rate.RateDate.Format(time.DateOnly))
+		}
+	}
+	return tx.Commit()
+}
+
+// rate units of the currency per 1 EUR effective on the date
+func (f *fxRateService) rate(ctx context.Context, currency string, on time.Time) (float64, error) {
+	if currency == BaseCurrency {
+		return 1, nil
+	}
+	day := on.UTC().Format(time.DateOnly)
+	key := currency + day
+	if rate, ok := f.cachedRate(key); ok {
+		return rate, nil
+	}
+
+	fxRate, err := models.FxRates(
+		models.FxRateWhere.Currency.EQ(currency),
+		qm.Where(models.FxRateColumns.RateDate+" <= ?", day),
+		qm.OrderBy(models.FxRateColumns.RateDate+" desc")).One(ctx, f.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errors.Wrapf(ErrNoFxRate, "%s on %s", currency, day)
+		}
+		return 0, err
+	}
+
+	f.cacheRate(key, fxRate.Rate)
+	return fxRate.Rate, nil
+}
+
+// cachedRate the cached rate if it has not expired, an expired one is evicted
+func (f *fxRateService) cachedRate(key string) (float64, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cached, ok := f.cache[key]
+	if !ok {
+		return 0, false
+	}
+	if !cached.expires.After(time.Now()) {
+		delete(f.cache, key)
+		return 0, false
+	}
+	return cached.rate, true
+}
+
+// cacheRate caches the rate, evicting the expired entries of other currencies and days so the cache only holds the
+// rates looked up within the last fxRateCacheTTL
+func (f *fxRateService) cacheRate(key string, rate float64) {
+	now := time.Now()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for k, cached := range f.cache {
+		if !cached.expires.After(now) {
+			delete(f.cache, k)
+		}
+	}
+	f.cache[key] = cachedFxRate{rate: rate, expires: now.Add(fxRateCacheTTL)}
+}
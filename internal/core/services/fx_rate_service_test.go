@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseECBCSV(t *testing.T) {
+	const csv = `Date, USD, JPY, TRY, CYP,
+18 October 2024, 1.0866, 162.52, 37.1872, N/A,
+17 October 2024, 1.0847, 162.31, 37.1241, N/A,
+`
+	rates, err := ParseECBCSV(strings.NewReader(csv))
+
+	require.NoError(t, err)
+	require.Len(t, rates, 6)
+	assert.Equal(t, "USD", rates[0].Currency)
+	assert.Equal(t, time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC), rates[0].RateDate)
+	assert.Equal(t, 1.0866, rates[0].Rate)
+	assert.Equal(t, "TRY", rates[5].Currency)
+	assert.Equal(t, time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC), rates[5].RateDate)
+}
+
+func Test_ParseECBXML(t *testing.T) {
+	const xml = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2024-10-18">
+			<Cube currency="USD" rate="1.0866"/>
+			<Cube currency="TRY" rate="37.1872"/>
+		</Cube>
+		<Cube time="2024-10-17">
+			<Cube currency="USD" rate="1.0847"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+	rates, err := ParseECBXML(strings.NewReader(xml))
+
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+	assert.Equal(t, "TRY", rates[1].Currency)
+	assert.Equal(t, 37.1872, rates[1].Rate)
+	assert.Equal(t, time.Date(2024, 10, 17, 0, 0, 0, 0, time.UTC), rates[2].RateDate)
+}
+
+func Test_fxRateService_ConvertValuationSet(t *testing.T) {
+	on := time.Date(2024, 10, 18, 9, 30, 0, 0, time.UTC)
+	expires := time.Now().Add(time.Hour)
+	svc := &fxRateService{cache: map[string]cachedFxRate{
+		"USD2024-10-18": {rate: 1.1, expires: expires},
+		"TRY2024-10-18": {rate: 37.4, expires: expires},
+	}}
+	valSet := &core.ValuationSet{Currency: "TRY", Retail: 748000, TradeIn: 374000, UserDisplayPrice: 561000}
+
+	err := svc.ConvertValuationSet(context.Background(), valSet, "usd", on)
+
+	require.NoError(t, err)
+	assert.Equal(t, "USD", valSet.Currency)
+	assert.Equal(t, 22000, valSet.Retail)
+	assert.Equal(t, 11000, valSet.TradeIn)
+	assert.Equal(t, 16500, valSet.UserDisplayPrice)
+	assert.Equal(t, 0, valSet.RetailClean)
+}
+
+func Test_fxRateService_Convert_fromEUR(t *testing.T) {
+	svc := &fxRateService{cache: map[string]cachedFxRate{
+		"USD2024-10-18": {rate: 1.1, expires: time.Now().Add(time.Hour)},
+	}}
+
+	usd, err := svc.Convert(context.Background(), 100, "EUR", "USD", time.Date(2024, 10, 18, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.InDelta(t, 110.0, usd, 0.0001)
+}
+
+func Test_fxRateService_cache_evictsExpired(t *testing.T) {
+	svc := &fxRateService{cache: map[string]cachedFxRate{
+		"USD2024-10-17": {rate: 1.09, expires: time.Now().Add(-time.Minute)},
+		"TRY2024-10-17": {rate: 37.2, expires: time.Now().Add(-time.Minute)},
+		"GBP2024-10-18": {rate: 0.83, expires: time.Now().Add(time.Hour)},
+	}}
+
+	_, ok := svc.cachedRate("USD2024-10-17")
+	assert.False(t, ok, "expired")
+	assert.NotContains(t, svc.cache, "USD2024-10-17", "evicted on read")
+
+	svc.cacheRate("USD2024-10-18", 1.1)
+
+	assert.Len(t, svc.cache, 2)
+	assert.NotContains(t, svc.cache, "TRY2024-10-17", "evicted on write")
+	rate, ok := svc.cachedRate("USD2024-10-18")
+	assert.True(t, ok)
+	assert.Equal(t, 1.1, rate)
+	rate, ok = svc.cachedRate("GBP2024-10-18")
+	assert.True(t, ok)
+	assert.Equal(t, 0.83, rate)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,7 +16,7 @@ import (
 
 //go:generate mockgen -source google_api_service.go -destination mocks/google_api_service_mock.go
 type GoogleGeoAPIService interface {
-	GeoDecodeLatLong(lat, lng float64) (*MapsGeocodeResp, error)
+	GeoDecodeLatLong(ctx context.Context, lat, lng float64) (*MapsGeocodeResp, error)
 }
 
 func NewGoogleGeoAPIService(settings *config.Settings, logger *zerolog.Logger) GoogleGeoAPIService {
@@ -27,8 +28,13 @@ type googleGeoAPIService struct {
 	logger       *zerolog.Logger
 }
 
-func (dda *googleGeoAPIService) GeoDecodeLatLong(lat, lng float64) (*MapsGeocodeResp, error) {
-	resp, err := http.Get(fmt.Sprintf("https://maps.googleapis.com/maps/api/geocode/json?latlng=%f,%f&key=%s", lat, lng, dda.googleAPIKey))
+func (dda *googleGeoAPIService) GeoDecodeLatLong(ctx context.Context, lat, lng float64) (*MapsGeocodeResp, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("https://maps.googleapis.com/maps/api/geocode/json?latlng=%f,%f&key=%s", lat, lng, dda.googleAPIKey), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
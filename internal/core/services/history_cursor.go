@@ -0,0 +1,40 @@
+package services
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidCursor the history cursor could not be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// historyCursor points at the last valuation row of a history page. Rows are ordered by created_at then ksuid id, the id
+// breaks ties between rows created at the same time.
+type historyCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// encodeHistoryCursor returns an opaque url safe cursor
+func encodeHistoryCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+func decodeHistoryCursor(cursor string) (*historyCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+	createdAtStr, id, found := strings.Cut(string(b), "|")
+	if !found || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCursor, err.Error())
+	}
+	return &historyCursor{CreatedAt: createdAt, ID: id}, nil
+}
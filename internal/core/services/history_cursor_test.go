@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_historyCursor_roundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 3, 14, 15, 9, 26, 535897000, time.UTC)
+	id := ksuid.New().String()
+
+	cursor, err := decodeHistoryCursor(encodeHistoryCursor(createdAt, id))
+
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	assert.Equal(t, id, cursor.ID)
+}
+
+func Test_decodeHistoryCursor_invalid(t *testing.T) {
+	for _, c := range []string{"not base64!", "bm8tc2VwYXJhdG9y", encodeHistoryCursor(time.Now(), "")} {
+		_, err := decodeHistoryCursor(c)
+		assert.ErrorIs(t, err, ErrInvalidCursor, c)
+	}
+}
@@ -54,7 +54,7 @@ func (ls *locationService) GetGeoDecodedLocation(ctx context.Context, signals *c
 		return nil, errors.New("no location provided, lat long zero")
 	}
 	// decode the lat long with google
-	gl, err := ls.geoSvc.GeoDecodeLatLong(signals.CurrentLocationLatitude.Value, signals.CurrentLocationLongitude.Value)
+	gl, err := ls.geoSvc.GeoDecodeLatLong(ctx, signals.CurrentLocationLatitude.Value, signals.CurrentLocationLongitude.Value)
 	if err != nil {
 		return nil, err
 	}
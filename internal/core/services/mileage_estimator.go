@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/core/units"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/ericlagergren/decimal"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// EstMilesPerYear annual mileage for countries we have no average for
+const EstMilesPerYear = 12000.0
+
+// annualMilesByCountry average miles driven per year, by alpha-3 country code. From national travel surveys, rounded
+var annualMilesByCountry = map[string]float64{
+	"USA": 13500,
+	"CAN": 9500,
+	"MEX": 9900,
+	"GBR": 7100,
+	"IRL": 10400,
+	"DEU": 8400,
+	"FRA": 7600,
+	"ITA": 6900,
+	"ESP": 7800,
+	"PRT": 7500,
+	"NLD": 7800,
+	"BEL": 8700,
+	"AUT": 8100,
+	"CHE": 7800,
+	"SWE": 7500,
+	"NOR": 7500,
+	"DNK": 9000,
+	"FIN": 8700,
+	"POL": 7600,
+	"AUS": 8400,
+	"NZL": 7800,
+	"JPN": 4400,
+}
+
+// minExtrapolationAge the vehicle must have been driven this long at the last reading to extrapolate from its rate,
+// younger vehicles don't have a meaningful rate yet
+const minExtrapolationAge = 90 * 24 * time.Hour
+
+// odometerStaleAfter an odometer reported longer ago than this is extrapolated to the time of the request
+const odometerStaleAfter = 14 * 24 * time.Hour
+
+// odometerHistoryWindow how far back from a stale odometer reading the driving rate is observed
+const odometerHistoryWindow = 90 * 24 * time.Hour
+
+// minHistoryWindow the odometer history must span at least this long to give a driving rate
+const minHistoryWindow = 7 * 24 * time.Hour
+
+// fullConfidenceWindow a driving rate observed over this long is fully trusted before the extrapolation distance is
+// taken into account
+const fullConfidenceWindow = 60 * 24 * time.Hour
+
+// lifetimeRateConfidence the lifetime rate assumes the vehicle was driven evenly since it was new, trust it half as much
+const lifetimeRateConfidence = 0.5
+
+// MileageEstimate the mileage to request a valuation with and where it came from
+type MileageEstimate struct {
+	Miles  float64
+	Source core.MileageSource
+	// Extrapolation set when Source is extrapolated
+	Extrapolation *core.OdometerExtrapolation
+}
+
+// mileageReading an odometer the vehicle reported in the past
+type mileageReading struct {
+	Miles float64
+	At    time.Time
+}
+
+// AnnualMiles average miles driven per year in the alpha-2 or alpha-3 country, EstMilesPerYear if we don't know it
+func AnnualMiles(countryCode string) float64 {
+	if miles, ok := annualMilesByCountry[ConvertCountryToAlpha3(countryCode)]; ok {
+		return miles
+	}
+	return EstMilesPerYear
+}
+
+// estimateMileage the vehicle odometer if it reported one recently. A stale odometer, or the last reading when the
+// vehicle reports none, is extrapolated at the rate the odometer history shows it was driven, or else at the rate it
+// was driven since it was new. Falls back to its age times the country average. Vehicles are assumed in service from
+// the start of their model year.
+func estimateMileage(signals *core.SignalsLatest, history []core.TimeFloatValue, last *mileageReading, modelYear int, countryCode string, now time.Time) MileageEstimate {
+	if signals != nil {
+		if odo := signals.PowertrainTransmissionTravelledDistance; odo.Value > 0 {
+			if !odometerStale(signals, now) {
+				return MileageEstimate{Miles: units.KilometersOf(odo.Value).Miles(), Source: core.TelemetryMileage}
+			}
+			reading := mileageReading{Miles: units.KilometersOf(odo.Value).Miles(), At: odo.Timestamp}
+			if extrapolation := extrapolateFromHistory(reading, history, now); extrapolation != nil {
+				return extrapolatedEstimate(extrapolation, now)
+			}
+			last = &reading
+		}
+	}
+
+	inService := time.Date(modelYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if last != nil && last.Miles > 0 && last.At.Sub(inService) >= minExtrapolationAge && now.After(last.At) {
+		extrapolation := &core.OdometerExtrapolation{
+			ReadingAt:    last.At,
+			ReadingMiles: last.Miles,
+			WindowFrom:   inService,
+			WindowTo:     last.At,
+			MilesPerDay:  last.Miles / last.At.Sub(inService).Hours() * 24,
+		}
+		extrapolation.Confidence = lifetimeRateConfidence * extrapolationConfidence(last.At.Sub(inService), now.Sub(last.At))
+		return extrapolatedEstimate(extrapolation, now)
+	}
+
+	years := now.Sub(inService).Hours() / (24 * 365.25)
+	if years < 0 {
+		// next model year
+		years = 0
+	}
+	return MileageEstimate{Miles: years * AnnualMiles(countryCode), Source: core.CountryAverageMileage}
+}
+
+// odometerStale whether the latest odometer was reported too long ago to use as is. An odometer without a timestamp
+// is not stale.
+func odometerStale(signals *core.SignalsLatest, now time.Time) bool {
+	if signals == nil {
+		return false
+	}
+	ts := signals.PowertrainTransmissionTravelledDistance.Timestamp
+	return !ts.IsZero() && now.Sub(ts) > odometerStaleAfter
+}
+
+func extrapolatedEstimate(e *core.OdometerExtrapolation, now time.Time) MileageEstimate {
+	return MileageEstimate{
+		Miles:         e.ReadingMiles + e.MilesPerDay*now.Sub(e.ReadingAt).Hours()/24,
+		Source:        core.ExtrapolatedMileage,
+		Extrapolation: e,
+	}
+}
+
+// extrapolateFromHistory the driving rate between the first and last odometer in the history, nil if the history is
+// too short or the odometer went backwards
+func extrapolateFromHistory(reading mileageReading, history []core.TimeFloatValue, now time.Time) *core.OdometerExtrapolation {
+	var first, last *core.TimeFloatValue
+	for i := range history {
+		h := &history[i]
+		if h.Value <= 0 || h.Timestamp.After(reading.At) {
+			continue
+		}
+		if first == nil || h.Timestamp.Before(first.Timestamp) {
+			first = h
+		}
+		if last == nil || h.Timestamp.After(last.Timestamp) {
+			last = h
+		}
+	}
+	if first == nil || last.Timestamp.Sub(first.Timestamp) < minHistoryWindow || last.Value < first.Value {
+		return nil
+	}
+	window := last.Timestamp.Sub(first.Timestamp)
+	return &core.OdometerExtrapolation{
+		ReadingAt:    reading.At,
+		ReadingMiles: reading.Miles,
+		WindowFrom:   first.Timestamp,
+		WindowTo:     last.Timestamp,
+		FromHistory:  true,
+		MilesPerDay:  units.KilometersOf(last.Value-first.Value).Miles() / window.Hours() * 24,
+		Confidence:   extrapolationConfidence(window, now.Sub(reading.At)),
+	}
+}
+
+// extrapolationConfidence 0 to 1, grows with the window the rate was observed over up to fullConfidenceWindow and
+// halves when extrapolating as far past the reading as the window is long
+func extrapolationConfidence(window, extrapolated time.Duration) float64 {
+	coverage := math.Min(window.Hours()/fullConfidenceWindow.Hours(), 1)
+	confidence := coverage / (1 + extrapolated.Hours()/window.Hours())
+	return math.Round(confidence*100) / 100
+}
+
+// odometerHistory the odometer history leading up to a stale odometer reading, nil if the odometer is fresh or the
+// history could not be fetched
+func odometerHistory(ctx context.Context, telemetryAPI gateways.TelemetryAPI, log *zerolog.Logger, tokenID uint64, authHeader string, signals *core.SignalsLatest, now time.Time) []core.TimeFloatValue {
+	if signals == nil || signals.PowertrainTransmissionTravelledDistance.Value <= 0 || !odometerStale(signals, now) {
+		return nil
+	}
+	readingAt := signals.PowertrainTransmissionTravelledDistance.Timestamp
+	history, err := telemetryAPI.GetOdometerHistory(ctx, tokenID, authHeader, readingAt.Add(-odometerHistoryWindow), readingAt.Add(time.Second))
+	if err != nil {
+		log.Warn().Err(err).Uint64("token_id", tokenID).Msg("could not get odometer history, extrapolating the stale odometer at the lifetime rate")
+		return nil
+	}
+	return history
+}
+
+// lastMileageReading the latest odometer the vehicle reported with a valuation request, nil if it never did
+func lastMileageReading(ctx context.Context, dbs func() *db.ReaderWriter, tokenID uint64) (*mileageReading, error) {
+	row, err := models.Valuations(
+		models.ValuationWhere.TokenID.EQ(types.NewNullDecimal(decimal.New(int64(tokenID), 0))),
+		models.ValuationWhere.MileageSource.EQ(null.StringFrom(core.TelemetryMileage.String())),
+		models.ValuationWhere.Mileage.IsNotNull(),
+		qm.OrderBy(models.ValuationColumns.CreatedAt+" desc")).One(ctx, dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get last mileage reading")
+	}
+	return &mileageReading{Miles: float64(row.Mileage.Int), At: row.CreatedAt}, nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_estimateMileage(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	staleAt := time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		signals     *core.SignalsLatest
+		history     []core.TimeFloatValue
+		last        *mileageReading
+		modelYear   int
+		countryCode string
+		wantMiles   float64
+		wantSource  core.MileageSource
+		// wantConfidence of the extrapolation, when extrapolated
+		wantConfidence float64
+	}{
+		{
+			name:       "odometer",
+			signals:    &core.SignalsLatest{PowertrainTransmissionTravelledDistance: core.TimeFloatValue{Value: 100000}},
+			modelYear:  2020,
+			wantMiles:  62137.1,
+			wantSource: core.TelemetryMileage,
+		},
+		{
+			name: "recent odometer",
+			signals: &core.SignalsLatest{PowertrainTransmissionTravelledDistance: core.TimeFloatValue{
+				Value: 100000, Timestamp: now.AddDate(0, 0, -3)}},
+			modelYear:  2020,
+			wantMiles:  62137.1,
+			wantSource: core.TelemetryMileage,
+		},
+		{
+			name: "stale odometer extrapolated from the history",
+			// 5000km over the 90 days before the reading, and 61 days since
+			signals: &core.SignalsLatest{PowertrainTransmissionTravelledDistance: core.TimeFloatValue{
+				Value: 50000, Timestamp: staleAt}},
+			history: []core.TimeFloatValue{
+				{Value: 45000, Timestamp: staleAt.AddDate(0, 0, -90)},
+				{Value: 47000, Timestamp: staleAt.AddDate(0, 0, -40)},
+				{Value: 50000, Timestamp: staleAt},
+			},
+			modelYear:      2020,
+			wantMiles:      33174.3,
+			wantSource:     core.ExtrapolatedMileage,
+			wantConfidence: 0.6,
+		},
+		{
+			name: "stale odometer with too short a history",
+			// lifetime rate, 1400 days in service at the reading
+			signals: &core.SignalsLatest{PowertrainTransmissionTravelledDistance: core.TimeFloatValue{
+				Value: 50000, Timestamp: staleAt}},
+			history: []core.TimeFloatValue{
+				{Value: 49900, Timestamp: staleAt.AddDate(0, 0, -2)},
+				{Value: 50000, Timestamp: staleAt},
+			},
+			last:           &mileageReading{Miles: 1000, At: time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			modelYear:      2020,
+			wantMiles:      32422.3,
+			wantSource:     core.ExtrapolatedMileage,
+			wantConfidence: 0.48,
+		},
+		{
+			name: "extrapolated from the last reading",
+			// 3 years in service at the reading, 10000 miles a year, and another year since
+			last:           &mileageReading{Miles: 30000, At: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			modelYear:      2020,
+			wantMiles:      40000,
+			wantSource:     core.ExtrapolatedMileage,
+			wantConfidence: 0.375,
+		},
+		{
+			name:        "reading too soon after the vehicle was new",
+			last:        &mileageReading{Miles: 300, At: time.Date(2020, time.January, 10, 0, 0, 0, 0, time.UTC)},
+			modelYear:   2020,
+			countryCode: "DE",
+			wantMiles:   4 * 8400,
+			wantSource:  core.CountryAverageMileage,
+		},
+		{
+			name:       "unknown country",
+			signals:    &core.SignalsLatest{},
+			modelYear:  2021,
+			wantMiles:  3 * EstMilesPerYear,
+			wantSource: core.CountryAverageMileage,
+		},
+		{
+			name:       "next model year",
+			modelYear:  2025,
+			wantMiles:  0,
+			wantSource: core.CountryAverageMileage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate := estimateMileage(tt.signals, tt.history, tt.last, tt.modelYear, tt.countryCode, now)
+
+			assert.Equal(t, tt.wantSource, estimate.Source)
+			// model years are a whole number of days, not of 365.25 day years
+			assert.InDelta(t, tt.wantMiles, estimate.Miles, 0.001*tt.wantMiles+0.1)
+			if tt.wantSource == core.ExtrapolatedMileage {
+				assert.Equal(t, tt.wantConfidence, estimate.Extrapolation.Confidence)
+			} else {
+				assert.Nil(t, estimate.Extrapolation)
+			}
+		})
+	}
+}
+
+func Test_AnnualMiles(t *testing.T) {
+	assert.Equal(t, 13500.0, AnnualMiles("US"))
+	assert.Equal(t, 13500.0, AnnualMiles("USA"))
+	assert.Equal(t, EstMilesPerYear, AnnualMiles("BRA"))
+	assert.Equal(t, EstMilesPerYear, AnnualMiles(""))
+}
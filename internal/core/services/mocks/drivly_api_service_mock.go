@@ -10,6 +10,7 @@
 package mock_services
 
 import (
+	context "context"
 	reflect "reflect"
 
 	models "github.com/DIMO-Network/valuations-api/internal/core/models"
@@ -40,211 +41,211 @@ func (m *MockDrivlyAPIService) EXPECT() *MockDrivlyAPIServiceMockRecorder {
 }
 
 // GetAutocheckByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetAutocheckByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetAutocheckByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAutocheckByVIN", vin)
+	ret := m.ctrl.Call(m, "GetAutocheckByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAutocheckByVIN indicates an expected call of GetAutocheckByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetAutocheckByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetAutocheckByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutocheckByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetAutocheckByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutocheckByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetAutocheckByVIN), ctx, vin)
 }
 
 // GetBuildByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetBuildByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetBuildByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBuildByVIN", vin)
+	ret := m.ctrl.Call(m, "GetBuildByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBuildByVIN indicates an expected call of GetBuildByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetBuildByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetBuildByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBuildByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetBuildByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBuildByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetBuildByVIN), ctx, vin)
 }
 
 // GetCargurusByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetCargurusByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetCargurusByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCargurusByVIN", vin)
+	ret := m.ctrl.Call(m, "GetCargurusByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCargurusByVIN indicates an expected call of GetCargurusByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetCargurusByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetCargurusByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCargurusByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCargurusByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCargurusByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCargurusByVIN), ctx, vin)
 }
 
 // GetCarmaxByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetCarmaxByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetCarmaxByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCarmaxByVIN", vin)
+	ret := m.ctrl.Call(m, "GetCarmaxByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCarmaxByVIN indicates an expected call of GetCarmaxByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetCarmaxByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetCarmaxByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarmaxByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCarmaxByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarmaxByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCarmaxByVIN), ctx, vin)
 }
 
 // GetCarstoryByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetCarstoryByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetCarstoryByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCarstoryByVIN", vin)
+	ret := m.ctrl.Call(m, "GetCarstoryByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCarstoryByVIN indicates an expected call of GetCarstoryByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetCarstoryByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetCarstoryByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarstoryByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCarstoryByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarstoryByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCarstoryByVIN), ctx, vin)
 }
 
 // GetCarvanaByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetCarvanaByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetCarvanaByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCarvanaByVIN", vin)
+	ret := m.ctrl.Call(m, "GetCarvanaByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCarvanaByVIN indicates an expected call of GetCarvanaByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetCarvanaByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetCarvanaByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarvanaByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCarvanaByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCarvanaByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetCarvanaByVIN), ctx, vin)
 }
 
 // GetEdmundsByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetEdmundsByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetEdmundsByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEdmundsByVIN", vin)
+	ret := m.ctrl.Call(m, "GetEdmundsByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetEdmundsByVIN indicates an expected call of GetEdmundsByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetEdmundsByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetEdmundsByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEdmundsByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetEdmundsByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEdmundsByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetEdmundsByVIN), ctx, vin)
 }
 
 // GetExtendedOffersByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetExtendedOffersByVIN(vin string) (*models.DrivlyVINSummary, error) {
+func (m *MockDrivlyAPIService) GetExtendedOffersByVIN(ctx context.Context, vin string) (*models.DrivlyVINSummary, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExtendedOffersByVIN", vin)
+	ret := m.ctrl.Call(m, "GetExtendedOffersByVIN", ctx, vin)
 	ret0, _ := ret[0].(*models.DrivlyVINSummary)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetExtendedOffersByVIN indicates an expected call of GetExtendedOffersByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetExtendedOffersByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetExtendedOffersByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExtendedOffersByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetExtendedOffersByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExtendedOffersByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetExtendedOffersByVIN), ctx, vin)
 }
 
 // GetKBBByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetKBBByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetKBBByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKBBByVIN", vin)
+	ret := m.ctrl.Call(m, "GetKBBByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetKBBByVIN indicates an expected call of GetKBBByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetKBBByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetKBBByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKBBByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetKBBByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKBBByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetKBBByVIN), ctx, vin)
 }
 
 // GetOffersByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetOffersByVIN(vin string, reqData *models.ValuationRequestData) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetOffersByVIN(ctx context.Context, vin string, reqData *models.ValuationRequestData) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOffersByVIN", vin, reqData)
+	ret := m.ctrl.Call(m, "GetOffersByVIN", ctx, vin, reqData)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetOffersByVIN indicates an expected call of GetOffersByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetOffersByVIN(vin, reqData any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetOffersByVIN(ctx, vin, reqData any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffersByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetOffersByVIN), vin, reqData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffersByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetOffersByVIN), ctx, vin, reqData)
 }
 
 // GetTMVByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetTMVByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetTMVByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTMVByVIN", vin)
+	ret := m.ctrl.Call(m, "GetTMVByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTMVByVIN indicates an expected call of GetTMVByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetTMVByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetTMVByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTMVByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetTMVByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTMVByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetTMVByVIN), ctx, vin)
 }
 
 // GetVINInfo mocks base method.
-func (m *MockDrivlyAPIService) GetVINInfo(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetVINInfo(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVINInfo", vin)
+	ret := m.ctrl.Call(m, "GetVINInfo", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVINInfo indicates an expected call of GetVINInfo.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetVINInfo(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetVINInfo(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVINInfo", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetVINInfo), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVINInfo", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetVINInfo), ctx, vin)
 }
 
 // GetVINPricing mocks base method.
-func (m *MockDrivlyAPIService) GetVINPricing(vin string, reqData *models.ValuationRequestData) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetVINPricing(ctx context.Context, vin string, reqData *models.ValuationRequestData) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVINPricing", vin, reqData)
+	ret := m.ctrl.Call(m, "GetVINPricing", ctx, vin, reqData)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVINPricing indicates an expected call of GetVINPricing.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetVINPricing(vin, reqData any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetVINPricing(ctx, vin, reqData any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVINPricing", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetVINPricing), vin, reqData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVINPricing", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetVINPricing), ctx, vin, reqData)
 }
 
 // GetVRoomByVIN mocks base method.
-func (m *MockDrivlyAPIService) GetVRoomByVIN(vin string) (map[string]any, error) {
+func (m *MockDrivlyAPIService) GetVRoomByVIN(ctx context.Context, vin string) (map[string]any, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetVRoomByVIN", vin)
+	ret := m.ctrl.Call(m, "GetVRoomByVIN", ctx, vin)
 	ret0, _ := ret[0].(map[string]any)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetVRoomByVIN indicates an expected call of GetVRoomByVIN.
-func (mr *MockDrivlyAPIServiceMockRecorder) GetVRoomByVIN(vin any) *gomock.Call {
+func (mr *MockDrivlyAPIServiceMockRecorder) GetVRoomByVIN(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVRoomByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetVRoomByVIN), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVRoomByVIN", reflect.TypeOf((*MockDrivlyAPIService)(nil).GetVRoomByVIN), ctx, vin)
 }
@@ -12,8 +12,10 @@ package mock_services
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/DIMO-Network/valuations-api/internal/core/models"
+	models0 "github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,32 +42,116 @@ func (m *MockDrivlyValuationService) EXPECT() *MockDrivlyValuationServiceMockRec
 	return m.recorder
 }
 
+// MetadataColumn mocks base method.
+func (m *MockDrivlyValuationService) MetadataColumn() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MetadataColumn")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// MetadataColumn indicates an expected call of MetadataColumn.
+func (mr *MockDrivlyValuationServiceMockRecorder) MetadataColumn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetadataColumn", reflect.TypeOf((*MockDrivlyValuationService)(nil).MetadataColumn))
+}
+
+// Name mocks base method.
+func (m *MockDrivlyValuationService) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockDrivlyValuationServiceMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockDrivlyValuationService)(nil).Name))
+}
+
+// OfferCountries mocks base method.
+func (m *MockDrivlyValuationService) OfferCountries() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferCountries")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OfferCountries indicates an expected call of OfferCountries.
+func (mr *MockDrivlyValuationServiceMockRecorder) OfferCountries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferCountries", reflect.TypeOf((*MockDrivlyValuationService)(nil).OfferCountries))
+}
+
+// ProjectValuation mocks base method.
+func (m *MockDrivlyValuationService) ProjectValuation(valuation *models0.Valuation, countryCode string) *models.ValuationSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectValuation", valuation, countryCode)
+	ret0, _ := ret[0].(*models.ValuationSet)
+	return ret0
+}
+
+// ProjectValuation indicates an expected call of ProjectValuation.
+func (mr *MockDrivlyValuationServiceMockRecorder) ProjectValuation(valuation, countryCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectValuation", reflect.TypeOf((*MockDrivlyValuationService)(nil).ProjectValuation), valuation, countryCode)
+}
+
 // PullOffer mocks base method.
-func (m *MockDrivlyValuationService) PullOffer(ctx context.Context, tokenID uint64, vin, privJWTAuthHeader string) (models.DataPullStatusEnum, error) {
+func (m *MockDrivlyValuationService) PullOffer(ctx context.Context, tokenID uint64, vin string, telemetry models.VehicleTelemetry) (models.DataPullStatusEnum, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PullOffer", ctx, tokenID, vin, privJWTAuthHeader)
+	ret := m.ctrl.Call(m, "PullOffer", ctx, tokenID, vin, telemetry)
 	ret0, _ := ret[0].(models.DataPullStatusEnum)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PullOffer indicates an expected call of PullOffer.
-func (mr *MockDrivlyValuationServiceMockRecorder) PullOffer(ctx, tokenID, vin, privJWTAuthHeader any) *gomock.Call {
+func (mr *MockDrivlyValuationServiceMockRecorder) PullOffer(ctx, tokenID, vin, telemetry any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullOffer", reflect.TypeOf((*MockDrivlyValuationService)(nil).PullOffer), ctx, tokenID, vin, privJWTAuthHeader)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullOffer", reflect.TypeOf((*MockDrivlyValuationService)(nil).PullOffer), ctx, tokenID, vin, telemetry)
 }
 
 // PullValuation mocks base method.
-func (m *MockDrivlyValuationService) PullValuation(ctx context.Context, tokenID uint64, vin, privJWTAuthHeader string) (models.DataPullStatusEnum, error) {
+func (m *MockDrivlyValuationService) PullValuation(ctx context.Context, tokenID uint64, vin string, telemetry models.VehicleTelemetry) (models.DataPullStatusEnum, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PullValuation", ctx, tokenID, vin, privJWTAuthHeader)
+	ret := m.ctrl.Call(m, "PullValuation", ctx, tokenID, vin, telemetry)
 	ret0, _ := ret[0].(models.DataPullStatusEnum)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PullValuation indicates an expected call of PullValuation.
-func (mr *MockDrivlyValuationServiceMockRecorder) PullValuation(ctx, tokenID, vin, privJWTAuthHeader any) *gomock.Call {
+func (mr *MockDrivlyValuationServiceMockRecorder) PullValuation(ctx, tokenID, vin, telemetry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullValuation", reflect.TypeOf((*MockDrivlyValuationService)(nil).PullValuation), ctx, tokenID, vin, telemetry)
+}
+
+// RepullWindow mocks base method.
+func (m *MockDrivlyValuationService) RepullWindow() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepullWindow")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RepullWindow indicates an expected call of RepullWindow.
+func (mr *MockDrivlyValuationServiceMockRecorder) RepullWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepullWindow", reflect.TypeOf((*MockDrivlyValuationService)(nil).RepullWindow))
+}
+
+// SupportedCountries mocks base method.
+func (m *MockDrivlyValuationService) SupportedCountries() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportedCountries")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// SupportedCountries indicates an expected call of SupportedCountries.
+func (mr *MockDrivlyValuationServiceMockRecorder) SupportedCountries() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullValuation", reflect.TypeOf((*MockDrivlyValuationService)(nil).PullValuation), ctx, tokenID, vin, privJWTAuthHeader)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportedCountries", reflect.TypeOf((*MockDrivlyValuationService)(nil).SupportedCountries))
 }
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fx_rate_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source fx_rate_service.go -destination mocks/fx_rate_service_mock.go
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/DIMO-Network/valuations-api/internal/core/models"
+	models0 "github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFxRateService is a mock of FxRateService interface.
+type MockFxRateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFxRateServiceMockRecorder
+}
+
+// MockFxRateServiceMockRecorder is the mock recorder for MockFxRateService.
+type MockFxRateServiceMockRecorder struct {
+	mock *MockFxRateService
+}
+
+// NewMockFxRateService creates a new mock instance.
+func NewMockFxRateService(ctrl *gomock.Controller) *MockFxRateService {
+	mock := &MockFxRateService{ctrl: ctrl}
+	mock.recorder = &MockFxRateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFxRateService) EXPECT() *MockFxRateServiceMockRecorder {
+	return m.recorder
+}
+
+// Convert mocks base method.
+func (m *MockFxRateService) Convert(ctx context.Context, amount float64, from, to string, on time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", ctx, amount, from, to, on)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockFxRateServiceMockRecorder) Convert(ctx, amount, from, to, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockFxRateService)(nil).Convert), ctx, amount, from, to, on)
+}
+
+// ConvertValuationSet mocks base method.
+func (m *MockFxRateService) ConvertValuationSet(ctx context.Context, valSet *models.ValuationSet, to string, on time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertValuationSet", ctx, valSet, to, on)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConvertValuationSet indicates an expected call of ConvertValuationSet.
+func (mr *MockFxRateServiceMockRecorder) ConvertValuationSet(ctx, valSet, to, on any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertValuationSet", reflect.TypeOf((*MockFxRateService)(nil).ConvertValuationSet), ctx, valSet, to, on)
+}
+
+// SaveRates mocks base method.
+func (m *MockFxRateService) SaveRates(ctx context.Context, rates []*models0.FxRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRates", ctx, rates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRates indicates an expected call of SaveRates.
+func (mr *MockFxRateServiceMockRecorder) SaveRates(ctx, rates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRates", reflect.TypeOf((*MockFxRateService)(nil).SaveRates), ctx, rates)
+}
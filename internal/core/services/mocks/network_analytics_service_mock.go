@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: network_analytics_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source network_analytics_service.go -destination mocks/network_analytics_service_mock.go
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/DIMO-Network/valuations-api/internal/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNetworkAnalyticsService is a mock of NetworkAnalyticsService interface.
+type MockNetworkAnalyticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkAnalyticsServiceMockRecorder
+}
+
+// MockNetworkAnalyticsServiceMockRecorder is the mock recorder for MockNetworkAnalyticsService.
+type MockNetworkAnalyticsServiceMockRecorder struct {
+	mock *MockNetworkAnalyticsService
+}
+
+// NewMockNetworkAnalyticsService creates a new mock instance.
+func NewMockNetworkAnalyticsService(ctrl *gomock.Controller) *MockNetworkAnalyticsService {
+	mock := &MockNetworkAnalyticsService{ctrl: ctrl}
+	mock.recorder = &MockNetworkAnalyticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNetworkAnalyticsService) EXPECT() *MockNetworkAnalyticsServiceMockRecorder {
+	return m.recorder
+}
+
+// GetNetworkValuation mocks base method.
+func (m *MockNetworkAnalyticsService) GetNetworkValuation(ctx context.Context) (*models.NetworkValuation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkValuation", ctx)
+	ret0, _ := ret[0].(*models.NetworkValuation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkValuation indicates an expected call of GetNetworkValuation.
+func (mr *MockNetworkAnalyticsServiceMockRecorder) GetNetworkValuation(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkValuation", reflect.TypeOf((*MockNetworkAnalyticsService)(nil).GetNetworkValuation), ctx)
+}
+
+// TakeSnapshot mocks base method.
+func (m *MockNetworkAnalyticsService) TakeSnapshot(ctx context.Context, day time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeSnapshot", ctx, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TakeSnapshot indicates an expected call of TakeSnapshot.
+func (mr *MockNetworkAnalyticsServiceMockRecorder) TakeSnapshot(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeSnapshot", reflect.TypeOf((*MockNetworkAnalyticsService)(nil).TakeSnapshot), ctx, day)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: portfolio_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source portfolio_service.go -destination mocks/portfolio_service_mock.go
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/DIMO-Network/valuations-api/internal/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPortfolioService is a mock of PortfolioService interface.
+type MockPortfolioService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPortfolioServiceMockRecorder
+}
+
+// MockPortfolioServiceMockRecorder is the mock recorder for MockPortfolioService.
+type MockPortfolioServiceMockRecorder struct {
+	mock *MockPortfolioService
+}
+
+// NewMockPortfolioService creates a new mock instance.
+func NewMockPortfolioService(ctrl *gomock.Controller) *MockPortfolioService {
+	mock := &MockPortfolioService{ctrl: ctrl}
+	mock.recorder = &MockPortfolioServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPortfolioService) EXPECT() *MockPortfolioServiceMockRecorder {
+	return m.recorder
+}
+
+// GetOwnerPortfolio mocks base method.
+func (m *MockPortfolioService) GetOwnerPortfolio(ctx context.Context, owner, currency string) (*models.OwnerPortfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerPortfolio", ctx, owner, currency)
+	ret0, _ := ret[0].(*models.OwnerPortfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerPortfolio indicates an expected call of GetOwnerPortfolio.
+func (mr *MockPortfolioServiceMockRecorder) GetOwnerPortfolio(ctx, owner, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerPortfolio", reflect.TypeOf((*MockPortfolioService)(nil).GetOwnerPortfolio), ctx, owner, currency)
+}
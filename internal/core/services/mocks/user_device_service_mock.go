@@ -70,19 +70,34 @@ func (mr *MockUserDeviceAPIServiceMockRecorder) GetOffers(ctx, tokenID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffers", reflect.TypeOf((*MockUserDeviceAPIService)(nil).GetOffers), ctx, tokenID)
 }
 
+// GetValuationHistory mocks base method.
+func (m *MockUserDeviceAPIService) GetValuationHistory(ctx context.Context, tokenID uint64, privJWT string, query models.ValuationHistoryQuery) (*models.ValuationHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValuationHistory", ctx, tokenID, privJWT, query)
+	ret0, _ := ret[0].(*models.ValuationHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValuationHistory indicates an expected call of GetValuationHistory.
+func (mr *MockUserDeviceAPIServiceMockRecorder) GetValuationHistory(ctx, tokenID, privJWT, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuationHistory", reflect.TypeOf((*MockUserDeviceAPIService)(nil).GetValuationHistory), ctx, tokenID, privJWT, query)
+}
+
 // GetValuations mocks base method.
-func (m *MockUserDeviceAPIService) GetValuations(ctx context.Context, tokenID uint64, privJWT string) (*models.DeviceValuation, error) {
+func (m *MockUserDeviceAPIService) GetValuations(ctx context.Context, tokenID uint64, privJWT, currency string) (*models.DeviceValuation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetValuations", ctx, tokenID, privJWT)
+	ret := m.ctrl.Call(m, "GetValuations", ctx, tokenID, privJWT, currency)
 	ret0, _ := ret[0].(*models.DeviceValuation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetValuations indicates an expected call of GetValuations.
-func (mr *MockUserDeviceAPIServiceMockRecorder) GetValuations(ctx, tokenID, privJWT any) *gomock.Call {
+func (mr *MockUserDeviceAPIServiceMockRecorder) GetValuations(ctx, tokenID, privJWT, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuations", reflect.TypeOf((*MockUserDeviceAPIService)(nil).GetValuations), ctx, tokenID, privJWT)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuations", reflect.TypeOf((*MockUserDeviceAPIService)(nil).GetValuations), ctx, tokenID, privJWT, currency)
 }
 
 // LastRequestDidGiveError mocks base method.
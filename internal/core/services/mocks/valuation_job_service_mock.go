@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: valuation_job_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source valuation_job_service.go -destination mocks/valuation_job_service_mock.go
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/DIMO-Network/valuations-api/internal/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockValuationJobService is a mock of ValuationJobService interface.
+type MockValuationJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockValuationJobServiceMockRecorder
+}
+
+// MockValuationJobServiceMockRecorder is the mock recorder for MockValuationJobService.
+type MockValuationJobServiceMockRecorder struct {
+	mock *MockValuationJobService
+}
+
+// NewMockValuationJobService creates a new mock instance.
+func NewMockValuationJobService(ctrl *gomock.Controller) *MockValuationJobService {
+	mock := &MockValuationJobService{ctrl: ctrl}
+	mock.recorder = &MockValuationJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValuationJobService) EXPECT() *MockValuationJobServiceMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockValuationJobService) Enqueue(ctx context.Context, jobType models.JobType, tokenID uint64, vin, countryCode, privJWTAuthHeader string, signals *models.SignalsLatest) (*models.ValuationJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, jobType, tokenID, vin, countryCode, privJWTAuthHeader, signals)
+	ret0, _ := ret[0].(*models.ValuationJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockValuationJobServiceMockRecorder) Enqueue(ctx, jobType, tokenID, vin, countryCode, privJWTAuthHeader, signals any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockValuationJobService)(nil).Enqueue), ctx, jobType, tokenID, vin, countryCode, privJWTAuthHeader, signals)
+}
+
+// GetJob mocks base method.
+func (m *MockValuationJobService) GetJob(ctx context.Context, jobID string) (*models.ValuationJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, jobID)
+	ret0, _ := ret[0].(*models.ValuationJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockValuationJobServiceMockRecorder) GetJob(ctx, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockValuationJobService)(nil).GetJob), ctx, jobID)
+}
+
+// RunWorker mocks base method.
+func (m *MockValuationJobService) RunWorker(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunWorker", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunWorker indicates an expected call of RunWorker.
+func (mr *MockValuationJobServiceMockRecorder) RunWorker(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunWorker", reflect.TypeOf((*MockValuationJobService)(nil).RunWorker), ctx)
+}
@@ -0,0 +1,194 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: valuation_provider.go
+//
+// Generated by this command:
+//
+//	mockgen -source valuation_provider.go -destination mocks/valuation_provider_mock.go
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/DIMO-Network/valuations-api/internal/core/models"
+	models0 "github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockValuationProvider is a mock of ValuationProvider interface.
+type MockValuationProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockValuationProviderMockRecorder
+}
+
+// MockValuationProviderMockRecorder is the mock recorder for MockValuationProvider.
+type MockValuationProviderMockRecorder struct {
+	mock *MockValuationProvider
+}
+
+// NewMockValuationProvider creates a new mock instance.
+func NewMockValuationProvider(ctrl *gomock.Controller) *MockValuationProvider {
+	mock := &MockValuationProvider{ctrl: ctrl}
+	mock.recorder = &MockValuationProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValuationProvider) EXPECT() *MockValuationProviderMockRecorder {
+	return m.recorder
+}
+
+// MetadataColumn mocks base method.
+func (m *MockValuationProvider) MetadataColumn() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MetadataColumn")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// MetadataColumn indicates an expected call of MetadataColumn.
+func (mr *MockValuationProviderMockRecorder) MetadataColumn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetadataColumn", reflect.TypeOf((*MockValuationProvider)(nil).MetadataColumn))
+}
+
+// Name mocks base method.
+func (m *MockValuationProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockValuationProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockValuationProvider)(nil).Name))
+}
+
+// ProjectValuation mocks base method.
+func (m *MockValuationProvider) ProjectValuation(valuation *models0.Valuation, countryCode string) *models.ValuationSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectValuation", valuation, countryCode)
+	ret0, _ := ret[0].(*models.ValuationSet)
+	return ret0
+}
+
+// ProjectValuation indicates an expected call of ProjectValuation.
+func (mr *MockValuationProviderMockRecorder) ProjectValuation(valuation, countryCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectValuation", reflect.TypeOf((*MockValuationProvider)(nil).ProjectValuation), valuation, countryCode)
+}
+
+// PullValuation mocks base method.
+func (m *MockValuationProvider) PullValuation(ctx context.Context, tokenID uint64, vin string, telemetry models.VehicleTelemetry) (models.DataPullStatusEnum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullValuation", ctx, tokenID, vin, telemetry)
+	ret0, _ := ret[0].(models.DataPullStatusEnum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullValuation indicates an expected call of PullValuation.
+func (mr *MockValuationProviderMockRecorder) PullValuation(ctx, tokenID, vin, telemetry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullValuation", reflect.TypeOf((*MockValuationProvider)(nil).PullValuation), ctx, tokenID, vin, telemetry)
+}
+
+// RepullWindow mocks base method.
+func (m *MockValuationProvider) RepullWindow() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepullWindow")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RepullWindow indicates an expected call of RepullWindow.
+func (mr *MockValuationProviderMockRecorder) RepullWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepullWindow", reflect.TypeOf((*MockValuationProvider)(nil).RepullWindow))
+}
+
+// SupportedCountries mocks base method.
+func (m *MockValuationProvider) SupportedCountries() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportedCountries")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// SupportedCountries indicates an expected call of SupportedCountries.
+func (mr *MockValuationProviderMockRecorder) SupportedCountries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportedCountries", reflect.TypeOf((*MockValuationProvider)(nil).SupportedCountries))
+}
+
+// MockOfferProvider is a mock of OfferProvider interface.
+type MockOfferProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockOfferProviderMockRecorder
+}
+
+// MockOfferProviderMockRecorder is the mock recorder for MockOfferProvider.
+type MockOfferProviderMockRecorder struct {
+	mock *MockOfferProvider
+}
+
+// NewMockOfferProvider creates a new mock instance.
+func NewMockOfferProvider(ctrl *gomock.Controller) *MockOfferProvider {
+	mock := &MockOfferProvider{ctrl: ctrl}
+	mock.recorder = &MockOfferProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOfferProvider) EXPECT() *MockOfferProviderMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockOfferProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockOfferProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockOfferProvider)(nil).Name))
+}
+
+// OfferCountries mocks base method.
+func (m *MockOfferProvider) OfferCountries() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferCountries")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// OfferCountries indicates an expected call of OfferCountries.
+func (mr *MockOfferProviderMockRecorder) OfferCountries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferCountries", reflect.TypeOf((*MockOfferProvider)(nil).OfferCountries))
+}
+
+// PullOffer mocks base method.
+func (m *MockOfferProvider) PullOffer(ctx context.Context, tokenID uint64, vin string, telemetry models.VehicleTelemetry) (models.DataPullStatusEnum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullOffer", ctx, tokenID, vin, telemetry)
+	ret0, _ := ret[0].(models.DataPullStatusEnum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullOffer indicates an expected call of PullOffer.
+func (mr *MockOfferProviderMockRecorder) PullOffer(ctx, tokenID, vin, telemetry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullOffer", reflect.TypeOf((*MockOfferProvider)(nil).PullOffer), ctx, tokenID, vin, telemetry)
+}
@@ -10,6 +10,7 @@
 package mock_services
 
 import (
+	context "context"
 	reflect "reflect"
 
 	models "github.com/DIMO-Network/valuations-api/internal/core/models"
@@ -40,16 +41,16 @@ func (m *MockVincarioAPIService) EXPECT() *MockVincarioAPIServiceMockRecorder {
 }
 
 // GetMarketValuation mocks base method.
-func (m *MockVincarioAPIService) GetMarketValuation(vin string) (*models.VincarioMarketValueResponse, error) {
+func (m *MockVincarioAPIService) GetMarketValuation(ctx context.Context, vin string) (*models.VincarioMarketValueResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMarketValuation", vin)
+	ret := m.ctrl.Call(m, "GetMarketValuation", ctx, vin)
 	ret0, _ := ret[0].(*models.VincarioMarketValueResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMarketValuation indicates an expected call of GetMarketValuation.
-func (mr *MockVincarioAPIServiceMockRecorder) GetMarketValuation(vin any) *gomock.Call {
+func (mr *MockVincarioAPIServiceMockRecorder) GetMarketValuation(ctx, vin any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketValuation", reflect.TypeOf((*MockVincarioAPIService)(nil).GetMarketValuation), vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketValuation", reflect.TypeOf((*MockVincarioAPIService)(nil).GetMarketValuation), ctx, vin)
 }
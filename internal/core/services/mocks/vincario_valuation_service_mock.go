@@ -12,8 +12,10 @@ package mock_services
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/DIMO-Network/valuations-api/internal/core/models"
+	models0 "github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,17 +42,87 @@ func (m *MockVincarioValuationService) EXPECT() *MockVincarioValuationServiceMoc
 	return m.recorder
 }
 
+// MetadataColumn mocks base method.
+func (m *MockVincarioValuationService) MetadataColumn() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MetadataColumn")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// MetadataColumn indicates an expected call of MetadataColumn.
+func (mr *MockVincarioValuationServiceMockRecorder) MetadataColumn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetadataColumn", reflect.TypeOf((*MockVincarioValuationService)(nil).MetadataColumn))
+}
+
+// Name mocks base method.
+func (m *MockVincarioValuationService) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockVincarioValuationServiceMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockVincarioValuationService)(nil).Name))
+}
+
+// ProjectValuation mocks base method.
+func (m *MockVincarioValuationService) ProjectValuation(valuation *models0.Valuation, countryCode string) *models.ValuationSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProjectValuation", valuation, countryCode)
+	ret0, _ := ret[0].(*models.ValuationSet)
+	return ret0
+}
+
+// ProjectValuation indicates an expected call of ProjectValuation.
+func (mr *MockVincarioValuationServiceMockRecorder) ProjectValuation(valuation, countryCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProjectValuation", reflect.TypeOf((*MockVincarioValuationService)(nil).ProjectValuation), valuation, countryCode)
+}
+
 // PullValuation mocks base method.
-func (m *MockVincarioValuationService) PullValuation(ctx context.Context, tokenID uint64, vin string) (models.DataPullStatusEnum, error) {
+func (m *MockVincarioValuationService) PullValuation(ctx context.Context, tokenID uint64, vin string, telemetry models.VehicleTelemetry) (models.DataPullStatusEnum, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PullValuation", ctx, tokenID, vin)
+	ret := m.ctrl.Call(m, "PullValuation", ctx, tokenID, vin, telemetry)
 	ret0, _ := ret[0].(models.DataPullStatusEnum)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PullValuation indicates an expected call of PullValuation.
-func (mr *MockVincarioValuationServiceMockRecorder) PullValuation(ctx, tokenID, vin any) *gomock.Call {
+func (mr *MockVincarioValuationServiceMockRecorder) PullValuation(ctx, tokenID, vin, telemetry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullValuation", reflect.TypeOf((*MockVincarioValuationService)(nil).PullValuation), ctx, tokenID, vin, telemetry)
+}
+
+// RepullWindow mocks base method.
+func (m *MockVincarioValuationService) RepullWindow() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepullWindow")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RepullWindow indicates an expected call of RepullWindow.
+func (mr *MockVincarioValuationServiceMockRecorder) RepullWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepullWindow", reflect.TypeOf((*MockVincarioValuationService)(nil).RepullWindow))
+}
+
+// SupportedCountries mocks base method.
+func (m *MockVincarioValuationService) SupportedCountries() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportedCountries")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// SupportedCountries indicates an expected call of SupportedCountries.
+func (mr *MockVincarioValuationServiceMockRecorder) SupportedCountries() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullValuation", reflect.TypeOf((*MockVincarioValuationService)(nil).PullValuation), ctx, tokenID, vin)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportedCountries", reflect.TypeOf((*MockVincarioValuationService)(nil).SupportedCountries))
 }
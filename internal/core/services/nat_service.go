@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
@@ -21,8 +22,13 @@ type NATSService struct {
 	AckTimeout               time.Duration
 	ValuationDurableConsumer string
 	OfferDurableConsumer     string
+	// JobsBucket key value bucket with the status of each job, by job id
+	JobsBucket nats.KeyValue
 }
 
+// jobsTTL how long job statuses are kept
+const jobsTTL = time.Hour * 24 * 7
+
 func NewNATSService(settings *config.Settings, log *zerolog.Logger) (*NATSService, error) {
 	n, err := nats.Connect(settings.NATSURL)
 	if err != nil {
@@ -56,6 +62,20 @@ func NewNATSService(settings *config.Settings, log *zerolog.Logger) (*NATSServic
 		}
 	}
 
+	jobs, err := js.KeyValue(settings.NATSJobsBucket)
+	if err != nil {
+		if !errors.Is(err, nats.ErrBucketNotFound) {
+			return nil, err
+		}
+		jobs, err = js.CreateKeyValue(&nats.KeyValueConfig{
+			Bucket: settings.NATSJobsBucket,
+			TTL:    jobsTTL,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create jobs bucket "+settings.NATSJobsBucket)
+		}
+	}
+
 	to, err := time.ParseDuration(settings.NATSAckTimeout)
 	if err != nil {
 		return nil, err
@@ -70,7 +90,14 @@ func NewNATSService(settings *config.Settings, log *zerolog.Logger) (*NATSServic
 		AckTimeout:               to,
 		ValuationDurableConsumer: settings.NATSValuationDurableConsumer,
 		OfferDurableConsumer:     settings.NATSOfferDurableConsumer,
+		JobsBucket:               jobs,
 	}
 
 	return natsSvc, nil
 }
+
+// Ping errors when nats is unreachable or the stream is gone
+func (n *NATSService) Ping(ctx context.Context) error {
+	_, err := n.JetStream.StreamInfo(n.JetStreamName, nats.Context(ctx))
+	return err
+}
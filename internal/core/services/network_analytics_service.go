@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+//go:generate mockgen -source network_analytics_service.go -destination mocks/network_analytics_service_mock.go
+
+// NetworkAnalyticsService totals the latest valuation of every vehicle into daily snapshots, so growth and breakdowns
+// don't have to be recomputed from the vendor json on every call.
+type NetworkAnalyticsService interface {
+	// GetNetworkValuation totals from the latest snapshot, with growth from earlier snapshots. Snapshots are taken by the
+	// snapshot-valuations job, all zero if none has been taken yet
+	GetNetworkValuation(ctx context.Context) (*core.NetworkValuation, error)
+	// TakeSnapshot totals the latest valuation of each vehicle as of the end of the day, replacing any existing snapshot for the day
+	TakeSnapshot(ctx context.Context, day time.Time) error
+}
+
+// snapshotLockID postgres advisory lock key, held while a snapshot is written so concurrent snapshots of a day don't
+// collide
+const snapshotLockID int64 = 8210374420
+
+// snapshotBatchSize vehicles read at a time when taking a snapshot
+const snapshotBatchSize = 500
+
+// snapshot dimensions
+const (
+	networkDimension    = "network"
+	definitionDimension = "definition"
+	countryDimension    = "country"
+)
+
+type networkAnalyticsService struct {
+	dbs       func() *db.ReaderWriter
+	logger    *zerolog.Logger
+	providers *ValuationProviderRegistry
+	fx        FxRateService
+	batchSize int
+}
+
+func NewNetworkAnalyticsService(dbs func() *db.ReaderWriter, logger *zerolog.Logger, providers *ValuationProviderRegistry,
+	fx FxRateService) NetworkAnalyticsService {
+	return &networkAnalyticsService{
+		dbs:       dbs,
+		logger:    logger,
+		providers: providers,
+		fx:        fx,
+		batchSize: snapshotBatchSize,
+	}
+}
+
+func (a *networkAnalyticsService) GetNetworkValuation(ctx context.Context) (*core.NetworkValuation, error) {
+	nv := &core.NetworkValuation{
+		ByDefinition: []core.ValuationBreakdown{},
+		ByCountry:    []core.ValuationBreakdown{},
+	}
+	latest, err := models.ValuationSnapshots(
+		models.ValuationSnapshotWhere.Dimension.EQ(networkDimension),
+		models.ValuationSnapshotWhere.SnapshotDate.LTE(snapshotDay(time.Now())),
+		qm.OrderBy(models.ValuationSnapshotColumns.SnapshotDate+" desc")).One(ctx, a.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nv, nil
+		}
+		return nil, errors.Wrap(err, "failed to get latest snapshot")
+	}
+	day := latest.SnapshotDate
+
+	rows, err := models.ValuationSnapshots(models.ValuationSnapshotWhere.SnapshotDate.EQ(day)).All(ctx, a.dbs().Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest snapshot")
+	}
+	nv.SnapshotDate = day.Format(time.DateOnly)
+	for _, row := range rows {
+		switch row.Dimension {
+		case networkDimension:
+			nv.TotalUSD = row.TotalUsd
+			nv.VehicleCount = row.VehicleCount
+		case definitionDimension:
+			breakdown := core.ValuationBreakdown{Key: row.DimensionKey, TotalUSD: row.TotalUsd, VehicleCount: row.VehicleCount}
+			breakdown.Make, breakdown.Model, breakdown.Year = parseDefinitionID(row.DimensionKey)
+			nv.ByDefinition = append(nv.ByDefinition, breakdown)
+		case countryDimension:
+			nv.ByCountry = append(nv.ByCountry, core.ValuationBreakdown{Key: row.DimensionKey, TotalUSD: row.TotalUsd, VehicleCount: row.VehicleCount})
+		}
+	}
+	sortBreakdowns(nv.ByDefinition)
+	sortBreakdowns(nv.ByCountry)
+
+	if nv.WeekOverWeekGrowth, err = a.growthSince(ctx, nv.TotalUSD, day.AddDate(0, 0, -7)); err != nil {
+		return nil, err
+	}
+	if nv.MonthOverMonthGrowth, err = a.growthSince(ctx, nv.TotalUSD, day.AddDate(0, -1, 0)); err != nil {
+		return nil, err
+	}
+
+	return nv, nil
+}
+
+func (a *networkAnalyticsService) TakeSnapshot(ctx context.Context, day time.Time) error {
+	day = snapshotDay(day)
+	locations, err := models.GeodecodedLocations().All(ctx, a.dbs().Reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to get vehicle locations")
+	}
+	countries := make(map[uint64]string, len(locations))
+	for _, loc := range locations {
+		if loc.Country.Valid {
+			countries[uint64(loc.TokenID)] = ConvertCountryToAlpha3(loc.Country.String)
+		}
+	}
+
+	snapshots := map[[2]string]*models.ValuationSnapshot{}
+	add := func(dimension, key string, usd float64) {
+		s, ok := snapshots[[2]string{dimension, key}]
+		if !ok {
+			s = &models.ValuationSnapshot{SnapshotDate: day, Dimension: dimension, DimensionKey: key}
+			snapshots[[2]string{dimension, key}] = s
+		}
+		s.TotalUsd += usd
+		s.VehicleCount++
+	}
+	// a page of vehicles at a time, the rows carry the vendor json
+	lastVin := ""
+	for {
+		latest, err := models.Valuations(
+			qm.Select("distinct on (vin) *"),
+			a.providers.HasValuationData(),
+			models.ValuationWhere.CreatedAt.LT(day.AddDate(0, 0, 1)),
+			models.ValuationWhere.Vin.GT(lastVin),
+			qm.OrderBy("vin, created_at desc"),
+			qm.Limit(a.batchSize)).All(ctx, a.dbs().Reader)
+		if err != nil {
+			return errors.Wrap(err, "failed to get latest valuations")
+		}
+		for _, valuation := range latest {
+			country := ""
+			if tokenID, ok := valuation.TokenID.Uint64(); ok {
+				country = countries[tokenID]
+			}
+			// the country's blending strategy, as the vehicle's own valuations are projected with
+			valSet := a.providers.ProjectValuation(a.logger, valuation, country)
+			if valSet == nil {
+				continue
+			}
+			// the price shown to users, same as owner portfolios are valued at
+			value := float64(valSet.UserDisplayPrice)
+			// no currency in the vendor response, assume it's already USD
+			if valSet.Currency != "" {
+				value, err = a.fx.Convert(ctx, value, valSet.Currency, "USD", valuation.CreatedAt)
+				if err != nil {
+					a.logger.Warn().Err(err).Str("vin", valuation.Vin).Msg("failed to convert valuation to USD, skipping")
+					continue
+				}
+			}
+			add(networkDimension, "", value)
+			add(definitionDimension, valuation.DefinitionID.String, value)
+			add(countryDimension, country, value)
+		}
+		if len(latest) < a.batchSize {
+			break
+		}
+		lastVin = latest[len(latest)-1].Vin
+	}
+	// always record the network total so the day counts as snapshotted
+	if _, ok := snapshots[[2]string{networkDimension, ""}]; !ok {
+		snapshots[[2]string{networkDimension, ""}] = &models.ValuationSnapshot{SnapshotDate: day, Dimension: networkDimension}
+	}
+
+	tx, err := a.dbs().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+	// released with the transaction
+	if _, err := tx.ExecContext(ctx, "select pg_advisory_xact_lock($1)", snapshotLockID); err != nil {
+		return errors.Wrap(err, "failed to get snapshot lock")
+	}
+	if _, err := models.ValuationSnapshots(models.ValuationSnapshotWhere.SnapshotDate.EQ(day)).DeleteAll(ctx, tx); err != nil {
+		return errors.Wrap(err, "failed to delete existing snapshot")
+	}
+	for _, s := range snapshots {
+		if err := s.Insert(ctx, tx, boil.Infer()); err != nil {
+			return errors.Wrapf(err, "failed to insert %s snapshot", s.Dimension)
+		}
+	}
+	return tx.Commit()
+}
+
+// growthSince percent growth of total from the network total of the latest snapshot on or before the date, nil if
+// there is no snapshot to compare to
+func (a *networkAnalyticsService) growthSince(ctx context.Context, total float64, since time.Time) (*float64, error) {
+	prev, err := models.ValuationSnapshots(
+		models.ValuationSnapshotWhere.Dimension.EQ(networkDimension),
+		models.ValuationSnapshotWhere.SnapshotDate.LTE(since),
+		qm.OrderBy(models.ValuationSnapshotColumns.SnapshotDate+" desc")).One(ctx, a.dbs().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get previous snapshot")
+	}
+	return growthPercentage(total, prev.TotalUsd), nil
+}
+
+// growthPercentage percent change from previous to current, nil if previous is zero
+func growthPercentage(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	growth := (current - previous) / previous * 100
+	return &growth
+}
+
+// parseDefinitionID make, model and year from a definition id, eg. ford_escape_2022. Empty if it's not in that format
+func parseDefinitionID(definitionID string) (string, string, int) {
+	parts := strings.Split(definitionID, "_")
+	if len(parts) < 3 {
+		return "", "", 0
+	}
+	year, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return "", "", 0
+	}
+	return parts[0], strings.Join(parts[1:len(parts)-1], "_"), year
+}
+
+func sortBreakdowns(breakdowns []core.ValuationBreakdown) {
+	sort.Slice(breakdowns, func(i, j int) bool {
+		return breakdowns[i].TotalUSD > breakdowns[j].TotalUSD
+	})
+}
+
+func snapshotDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	mock_services "github.com/DIMO-Network/valuations-api/internal/core/services/mocks"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/mock/gomock"
+)
+
+type NetworkAnalyticsServiceTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	mockCtrl  *gomock.Controller
+	svc       *networkAnalyticsService
+}
+
+func (s *NetworkAnalyticsServiceTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container = dbtest.StartContainerDatabase(s.ctx, "valuations_api", s.T(), migrationsDirRelPath)
+}
+
+func (s *NetworkAnalyticsServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	fx := mock_services.NewMockFxRateService(s.mockCtrl)
+	fx.EXPECT().Convert(gomock.Any(), gomock.Any(), "USD", "USD", gomock.Any()).
+		DoAndReturn(func(_ context.Context, amount float64, _, _ string, _ time.Time) (float64, error) {
+			return amount, nil
+		}).AnyTimes()
+	s.svc = NewNetworkAnalyticsService(s.pdb.DBS, dbtest.Logger(), testProviders(), fx).(*networkAnalyticsService)
+	// page through one vehicle at a time
+	s.svc.batchSize = 1
+}
+
+// TearDownTest after each test truncate tables
+func (s *NetworkAnalyticsServiceTestSuite) TearDownTest() {
+	dbtest.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *NetworkAnalyticsServiceTestSuite) TearDownSuite() {
+	fmt.Printf("shutting down postgres at with session: %s \n", s.container.SessionID())
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func TestNetworkAnalyticsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(NetworkAnalyticsServiceTestSuite))
+}
+
+// createValuation a drivly valuation with its columns projected, valued at price USD
+func (s *NetworkAnalyticsServiceTestSuite) createValuation(tokenID uint64, vin, definitionID string, price int, createdAt time.Time) {
+	val := setupCreateValuationsData(s.T(), tokenID, definitionID, vin, map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+	}, nil)
+	val.Vendor = null.StringFrom(DrivlyProvider)
+	val.ColumnsVersion = null.IntFrom(valuationColumnsVersion)
+	val.TradeIn = null.IntFrom(price)
+	val.Retail = null.IntFrom(price)
+	val.UserDisplayPrice = null.IntFrom(price)
+	val.Currency = null.StringFrom("USD")
+	val.CreatedAt = createdAt
+	require.NoError(s.T(), val.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+}
+
+func (s *NetworkAnalyticsServiceTestSuite) createLocation(tokenID int64, country string) {
+	loc := models.GeodecodedLocation{TokenID: tokenID, Country: null.StringFrom(country)}
+	require.NoError(s.T(), loc.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+}
+
+func (s *NetworkAnalyticsServiceTestSuite) TestTakeSnapshot_GetNetworkValuation() {
+	day := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+	// valued again today, only the latest valuation counts
+	s.createValuation(1, "1FMCU9J94NUA00001", "ford_escape_2022", 10000, day.AddDate(0, 0, -8))
+	s.createValuation(1, "1FMCU9J94NUA00001", "ford_escape_2022", 20000, day.Add(time.Hour))
+	s.createValuation(2, "WVWZZZ3CZWE000002", "volkswagen_golf_2020", 5000, day.Add(2*time.Hour))
+	// no location
+	s.createValuation(3, "1FMCU9J94NUA00003", "ford_escape_2022", 10000, day.AddDate(0, 0, -40))
+	// after the day
+	s.createValuation(4, "1FMCU9J94NUA00004", "ford_escape_2022", 99000, day.AddDate(0, 0, 1))
+	s.createLocation(1, "US")
+	s.createLocation(2, "DE")
+
+	for _, d := range []time.Time{day.AddDate(0, -1, 0), day.AddDate(0, 0, -7), day} {
+		require.NoError(s.T(), s.svc.TakeSnapshot(s.ctx, d))
+	}
+	// taking it again replaces the day's snapshot
+	require.NoError(s.T(), s.svc.TakeSnapshot(s.ctx, day))
+
+	nv, err := s.svc.GetNetworkValuation(s.ctx)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), "2024-06-10", nv.SnapshotDate)
+	assert.Equal(s.T(), 35000.0, nv.TotalUSD)
+	assert.Equal(s.T(), 3, nv.VehicleCount)
+	assert.Equal(s.T(), []core.ValuationBreakdown{
+		{Key: "ford_escape_2022", Make: "ford", Model: "escape", Year: 2022, TotalUSD: 30000, VehicleCount: 2},
+		{Key: "volkswagen_golf_2020", Make: "volkswagen", Model: "golf", Year: 2020, TotalUSD: 5000, VehicleCount: 1},
+	}, nv.ByDefinition)
+	assert.Equal(s.T(), []core.ValuationBreakdown{
+		{Key: "USA", TotalUSD: 20000, VehicleCount: 1},
+		{Key: "", TotalUSD: 10000, VehicleCount: 1},
+		{Key: "DEU", TotalUSD: 5000, VehicleCount: 1},
+	}, nv.ByCountry)
+	// a week ago vehicles 1 and 3 were worth 20000, a month ago only 3 was valued at 10000
+	require.NotNil(s.T(), nv.WeekOverWeekGrowth)
+	assert.InDelta(s.T(), 75.0, *nv.WeekOverWeekGrowth, 0.0001)
+	require.NotNil(s.T(), nv.MonthOverMonthGrowth)
+	assert.InDelta(s.T(), 250.0, *nv.MonthOverMonthGrowth, 0.0001)
+}
+
+func (s *NetworkAnalyticsServiceTestSuite) TestGetNetworkValuation_noSnapshot() {
+	nv, err := s.svc.GetNetworkValuation(s.ctx)
+
+	require.NoError(s.T(), err)
+	assert.Zero(s.T(), nv.TotalUSD)
+	assert.Empty(s.T(), nv.SnapshotDate)
+	assert.Nil(s.T(), nv.WeekOverWeekGrowth)
+}
+
+func Test_growthPercentage(t *testing.T) {
+	growth := growthPercentage(110, 100)
+	require.NotNil(t, growth)
+	assert.InDelta(t, 10.0, *growth, 0.0001)
+
+	growth = growthPercentage(90, 120)
+	require.NotNil(t, growth)
+	assert.InDelta(t, -25.0, *growth, 0.0001)
+
+	assert.Nil(t, growthPercentage(100, 0))
+}
+
+func Test_parseDefinitionID(t *testing.T) {
+	mk, model, year := parseDefinitionID("ford_escape_2022")
+	assert.Equal(t, "ford", mk)
+	assert.Equal(t, "escape", model)
+	assert.Equal(t, 2022, year)
+
+	mk, model, year = parseDefinitionID("land-rover_range-rover_sport_2019")
+	assert.Equal(t, "land-rover", mk)
+	assert.Equal(t, "range-rover_sport", model)
+	assert.Equal(t, 2019, year)
+
+	for _, id := range []string{"", "ford_escape", "ford_escape_new"} {
+		mk, _, year = parseDefinitionID(id)
+		assert.Empty(t, mk, id)
+		assert.Zero(t, year, id)
+	}
+}
+
+func Test_sortBreakdowns(t *testing.T) {
+	breakdowns := []core.ValuationBreakdown{{Key: "DEU", TotalUSD: 10}, {Key: "USA", TotalUSD: 30}, {Key: "", TotalUSD: 20}}
+
+	sortBreakdowns(breakdowns)
+
+	assert.Equal(t, []string{"USA", "", "DEU"}, []string{breakdowns[0].Key, breakdowns[1].Key, breakdowns[2].Key})
+}
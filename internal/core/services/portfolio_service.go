@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+//go:generate mockgen -source portfolio_service.go -destination mocks/portfolio_service_mock.go
+
+// PortfolioService values every vehicle owned by an address, eg. a fleet
+type PortfolioService interface {
+	// GetOwnerPortfolio latest valuation of each vehicle the owner has, converted to currency, with totals
+	GetOwnerPortfolio(ctx context.Context, owner, currency string) (*core.OwnerPortfolio, error)
+}
+
+// DefaultPortfolioCurrency used when no currency is requested
+const DefaultPortfolioCurrency = "USD"
+
+type portfolioService struct {
+	dbs       func() *db.ReaderWriter
+	logger    *zerolog.Logger
+	identity  gateways.IdentityAPI
+	providers *ValuationProviderRegistry
+	fx        FxRateService
+}
+
+func NewPortfolioService(dbs func() *db.ReaderWriter, logger *zerolog.Logger, identity gateways.IdentityAPI,
+	providers *ValuationProviderRegistry, fx FxRateService) PortfolioService {
+	return &portfolioService{
+		dbs:       dbs,
+		logger:    logger,
+		identity:  identity,
+		providers: providers,
+		fx:        fx,
+	}
+}
+
+func (p *portfolioService) GetOwnerPortfolio(ctx context.Context, owner, currency string) (*core.OwnerPortfolio, error) {
+	if currency == "" {
+		currency = DefaultPortfolioCurrency
+	}
+	currency = strings.ToUpper(currency)
+	// fail fast on a currency we have no rates for, rather than leaving every vehicle unvalued
+	if _, err := p.fx.Convert(ctx, 1, BaseCurrency, currency, time.Now()); err != nil {
+		return nil, err
+	}
+	vehicles, err := p.identity.GetOwnerVehicles(ctx, owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get vehicles for owner %s", owner)
+	}
+
+	portfolio := &core.OwnerPortfolio{
+		Owner:    owner,
+		Currency: currency,
+		Vehicles: make([]core.PortfolioVehicle, len(vehicles)),
+	}
+	if len(vehicles) == 0 {
+		return portfolio, nil
+	}
+
+	latest, err := p.latestValuations(ctx, vehicles)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range vehicles {
+		pv := core.PortfolioVehicle{
+			TokenID:      v.TokenID,
+			DefinitionID: v.Definition.ID,
+			Make:         v.Definition.Make,
+			Model:        v.Definition.Model,
+			Year:         v.Definition.Year,
+		}
+		if valuation, ok := latest[v.TokenID]; ok {
+			p.value(ctx, &pv, valuation, currency)
+		}
+		portfolio.Vehicles[i] = pv
+	}
+	fillInEstimates(portfolio)
+
+	return portfolio, nil
+}
+
+// latestValuations latest valuation with data for each of the vehicles, by token id
+func (p *portfolioService) latestValuations(ctx context.Context, vehicles []core.Vehicle) (map[uint64]*models.Valuation, error) {
+	tokenIDs := make([]interface{}, len(vehicles))
+	for i, v := range vehicles {
+		tokenIDs[i] = int64(v.TokenID)
+	}
+	rows, err := models.Valuations(
+		qm.Select("distinct on (token_id) *"),
+		qm.WhereIn("token_id in ?", tokenIDs...),
+		p.providers.HasValuationData(),
+		qm.OrderBy("token_id, created_at desc")).All(ctx, p.dbs().Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest valuations")
+	}
+
+	latest := make(map[uint64]*models.Valuation, len(rows))
+	for _, row := range rows {
+		if tokenID, ok := row.TokenID.Uint64(); ok {
+			latest[tokenID] = row
+		}
+	}
+	return latest, nil
+}
+
+// value sets the vehicle value from the valuation, leaves it unvalued if it can't be projected or converted
+func (p *portfolioService) value(ctx context.Context, pv *core.PortfolioVehicle, valuation *models.Valuation, currency string) {
+	// the country it was requested in, so the price matches the one shown for the single vehicle
+	valSet := p.providers.ProjectValuation(p.logger, valuation, requestCountryCode(valuation))
+	if valSet == nil {
+		return
+	}
+	value := float64(valSet.UserDisplayPrice)
+	if valSet.Currency != "" {
+		var err error
+		value, err = p.fx.Convert(ctx, value, valSet.Currency, currency, valuation.CreatedAt)
+		if err != nil {
+			p.logger.Warn().Err(err).Uint64("token_id", pv.TokenID).Msgf("failed to convert valuation to %s", currency)
+			return
+		}
+	}
+	pv.Valued = true
+	pv.Value = int(math.Round(value))
+	pv.Vendor = valSet.Vendor
+	pv.Updated = valuation.CreatedAt.Format(time.RFC3339)
+}
+
+// fillInEstimates totals the valued vehicles and estimates the unvalued ones from the average value of valued vehicles
+// with the same definition, or of all valued vehicles if there are none.
+func fillInEstimates(portfolio *core.OwnerPortfolio) {
+	type avg struct{ sum, count int }
+	byDefinition := map[string]*avg{}
+	for _, v := range portfolio.Vehicles {
+		if !v.Valued {
+			portfolio.UnvaluedCount++
+			continue
+		}
+		portfolio.ValuedCount++
+		portfolio.TotalValue += v.Value
+		if byDefinition[v.DefinitionID] == nil {
+			byDefinition[v.DefinitionID] = &avg{}
+		}
+		byDefinition[v.DefinitionID].sum += v.Value
+		byDefinition[v.DefinitionID].count++
+	}
+
+	if portfolio.ValuedCount > 0 {
+		for i := range portfolio.Vehicles {
+			v := &portfolio.Vehicles[i]
+			if v.Valued {
+				continue
+			}
+			estimate := portfolio.TotalValue / portfolio.ValuedCount
+			if same, ok := byDefinition[v.DefinitionID]; ok && v.DefinitionID != "" {
+				estimate = same.sum / same.count
+			}
+			v.Value = estimate
+			v.Estimated = true
+			portfolio.EstimatedUnvaluedValue += estimate
+		}
+	}
+	portfolio.EstimatedTotalValue = portfolio.TotalValue + portfolio.EstimatedUnvaluedValue
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	mock_gateways "github.com/DIMO-Network/valuations-api/internal/core/gateways/mocks"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	mock_services "github.com/DIMO-Network/valuations-api/internal/core/services/mocks"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/dbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"go.uber.org/mock/gomock"
+)
+
+type PortfolioServiceTestSuite struct {
+	suite.Suite
+	pdb       db.Store
+	container testcontainers.Container
+	ctx       context.Context
+	mockCtrl  *gomock.Controller
+	identity  *mock_gateways.MockIdentityAPI
+	providers *ValuationProviderRegistry
+	svc       PortfolioService
+}
+
+func (s *PortfolioServiceTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+	s.pdb, s.container = dbtest.StartContainerDatabase(s.ctx, "valuations_api", s.T(), migrationsDirRelPath)
+}
+
+func (s *PortfolioServiceTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.identity = mock_gateways.NewMockIdentityAPI(s.mockCtrl)
+	fx := mock_services.NewMockFxRateService(s.mockCtrl)
+	fx.EXPECT().Convert(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, amount float64, _, _ string, _ time.Time) (float64, error) {
+			return amount, nil
+		}).AnyTimes()
+	blending, err := NewPriceBlending(&config.Settings{PriceBlendingRouting: "DEU:median"})
+	s.Require().NoError(err)
+	s.providers = NewValuationProviderRegistry(&drivlyValuationService{}, &vincarioValuationService{blending: blending})
+	s.svc = NewPortfolioService(s.pdb.DBS, dbtest.Logger(), s.identity, s.providers, fx)
+}
+
+// TearDownTest after each test truncate tables
+func (s *PortfolioServiceTestSuite) TearDownTest() {
+	dbtest.TruncateTables(s.pdb.DBS().Writer.DB, s.T())
+}
+
+// TearDownSuite cleanup at end by terminating container
+func (s *PortfolioServiceTestSuite) TearDownSuite() {
+	fmt.Printf("shutting down postgres at with session: %s \n", s.container.SessionID())
+	if err := s.container.Terminate(s.ctx); err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func TestPortfolioServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PortfolioServiceTestSuite))
+}
+
+func (s *PortfolioServiceTestSuite) TestGetOwnerPortfolio() {
+	const owner = "0x1234567890123456789012345678901234567890"
+	vehicles := make([]core.Vehicle, 2)
+	for i := range vehicles {
+		vehicles[i].TokenID = uint64(i + 1)
+		vehicles[i].Definition.ID = "volkswagen_golf_2020"
+	}
+	s.identity.EXPECT().GetOwnerVehicles(gomock.Any(), owner).Return(vehicles, nil)
+	older := setupCreateValuationsData(s.T(), 1, "volkswagen_golf_2020", "WVWZZZ3CZWE000001", map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+	}, nil)
+	older.CreatedAt = time.Now().AddDate(0, 0, -7)
+	s.Require().NoError(older.Insert(s.ctx, s.pdb.DBS().Writer, boil.Infer()))
+	latest := setupCreateValuationsData(s.T(), 1, "volkswagen_golf_2020", "WVWZZZ3CZWE000001", map[string][]byte{
+		"VincarioMetadata": []byte(testVincarioValuationJSON),
+		"RequestMetadata":  []byte(`{"countryCode": "DE"}`),
+	}, &s.pdb)
+
+	portfolio, err := s.svc.GetOwnerPortfolio(s.ctx, owner, "eur")
+
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "EUR", portfolio.Currency)
+	assert.Equal(s.T(), 1, portfolio.ValuedCount)
+	valued := portfolio.Vehicles[0]
+	assert.Equal(s.T(), VincarioProvider, valued.Vendor, "the latest valuation")
+	assert.Equal(s.T(), 31990, valued.Value, "blended with the strategy of the country it was requested in")
+	single := s.providers.ProjectValuation(dbtest.Logger(), latest, "DE")
+	assert.Equal(s.T(), single.UserDisplayPrice, valued.Value, "same as the single vehicle price")
+	assert.NotEqual(s.T(), s.providers.ProjectValuation(dbtest.Logger(), latest, "").UserDisplayPrice, valued.Value)
+	// no valuation, estimated from the other golf
+	assert.True(s.T(), portfolio.Vehicles[1].Estimated)
+	assert.Equal(s.T(), 63980, portfolio.EstimatedTotalValue)
+}
+
+func Test_fillInEstimates(t *testing.T) {
+	portfolio := &core.OwnerPortfolio{Vehicles: []core.PortfolioVehicle{
+		{TokenID: 1, DefinitionID: "ford_escape_2022", Valued: true, Value: 20000},
+		{TokenID: 2, DefinitionID: "ford_escape_2022", Valued: true, Value: 22000},
+		{TokenID: 3, DefinitionID: "tesla_model-3_2021", Valued: true, Value: 30000},
+		{TokenID: 4, DefinitionID: "ford_escape_2022"},
+		{TokenID: 5, DefinitionID: "bmw_x5_2020"},
+	}}
+
+	fillInEstimates(portfolio)
+
+	assert.Equal(t, 3, portfolio.ValuedCount)
+	assert.Equal(t, 2, portfolio.UnvaluedCount)
+	assert.Equal(t, 72000, portfolio.TotalValue)
+	// same definition average
+	assert.Equal(t, 21000, portfolio.Vehicles[3].Value)
+	assert.True(t, portfolio.Vehicles[3].Estimated)
+	// all valued average
+	assert.Equal(t, 24000, portfolio.Vehicles[4].Value)
+	assert.Equal(t, 45000, portfolio.EstimatedUnvaluedValue)
+	assert.Equal(t, 117000, portfolio.EstimatedTotalValue)
+}
+
+func Test_fillInEstimates_nothingValued(t *testing.T) {
+	portfolio := &core.OwnerPortfolio{Vehicles: []core.PortfolioVehicle{{TokenID: 1}, {TokenID: 2}}}
+
+	fillInEstimates(portfolio)
+
+	assert.Equal(t, 2, portfolio.UnvaluedCount)
+	assert.Equal(t, 0, portfolio.EstimatedTotalValue)
+	assert.False(t, portfolio.Vehicles[0].Estimated)
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	"github.com/pkg/errors"
+)
+
+// names of the blending strategies, recorded on the valuation set
+const (
+	VendorBlending   = "vendor"
+	MeanBlending     = "mean"
+	MedianBlending   = "median"
+	WeightedBlending = "weighted"
+	TrimmedBlending  = "trimmed"
+	StdevBlending    = "stdev"
+)
+
+const (
+	defaultTrimPercent = 10.0
+	defaultStdevLimit  = 2.0
+)
+
+// PricePoint a price the display price is blended from, eg. a book value or a market listing
+type PricePoint struct {
+	// Source of the price, eg. kbb for a drivly book or the market country of a vincario listing
+	Source string
+	Value  float64
+}
+
+// BlendInput the prices a vendor returned for a vehicle
+type BlendInput struct {
+	Points []PricePoint
+	// VendorPrice the display price as the vendor gives it, used when there are no points
+	VendorPrice float64
+	// Stdev standard deviation of the market the vendor reported, computed from the points when 0
+	Stdev float64
+}
+
+// BlendingStrategy combines the prices a vendor returned into the price to display to users
+type BlendingStrategy interface {
+	Name() string
+	Blend(input BlendInput) float64
+}
+
+// PriceBlending the blending strategy to use for each country
+type PriceBlending struct {
+	routing    ProviderRouting
+	strategies map[string]BlendingStrategy
+}
+
+// NewPriceBlending builds the strategies and country routing from the PRICE_BLENDING settings
+func NewPriceBlending(settings *config.Settings) (*PriceBlending, error) {
+	weights, err := parseBlendingWeights(settings.PriceBlendingWeights)
+	if err != nil {
+		return nil, err
+	}
+	trimPercent := settings.PriceBlendingTrimPercent
+	if trimPercent == 0 {
+		trimPercent = defaultTrimPercent
+	}
+	if trimPercent < 0 || trimPercent >= 50 {
+		return nil, errors.Errorf("invalid PRICE_BLENDING_TRIM_PERCENT %v, must be less than 50", trimPercent)
+	}
+	stdevLimit := settings.PriceBlendingStdevLimit
+	if stdevLimit == 0 {
+		stdevLimit = defaultStdevLimit
+	}
+	if stdevLimit < 0 {
+		return nil, errors.Errorf("invalid PRICE_BLENDING_STDEV_LIMIT %v", stdevLimit)
+	}
+
+	pb := &PriceBlending{strategies: map[string]BlendingStrategy{}}
+	for _, s := range []BlendingStrategy{
+		vendorBlending{},
+		meanBlending{},
+		medianBlending{},
+		weightedBlending{weights: weights},
+		trimmedBlending{percent: trimPercent},
+		stdevBlending{limit: stdevLimit},
+	} {
+		pb.strategies[s.Name()] = s
+	}
+
+	// same format as the provider routing, with a single strategy per route
+	routing, err := ParseProviderRouting(settings.PriceBlendingRouting)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid PRICE_BLENDING_ROUTING")
+	}
+	for _, names := range routing {
+		if len(names) != 1 {
+			return nil, errors.Errorf("invalid PRICE_BLENDING_ROUTING, expected one strategy per route, got %s", strings.Join(names, ","))
+		}
+		if _, ok := pb.strategies[names[0]]; !ok {
+			return nil, errors.Errorf("invalid PRICE_BLENDING_ROUTING, unknown strategy %s", names[0])
+		}
+	}
+	pb.routing = routing
+	return pb, nil
+}
+
+// ForCountry the strategy for the alpha-2 or alpha-3 country code, vendor if the country is not routed. Safe to call
+// on nil, which always returns vendor.
+func (pb *PriceBlending) ForCountry(countryCode string) BlendingStrategy {
+	if pb == nil {
+		return vendorBlending{}
+	}
+	if names, ok := pb.routing.Route(ConvertCountryToAlpha3(countryCode)); ok {
+		return pb.strategies[names[0]]
+	}
+	return pb.strategies[VendorBlending]
+}
+
+// parseBlendingWeights parses source weights, eg. blackbook:2,kbb:1.5
+func parseBlendingWeights(weights string) (map[string]float64, error) {
+	parsed := map[string]float64{}
+	for _, w := range strings.Split(weights, ",") {
+		if strings.TrimSpace(w) == "" {
+			continue
+		}
+		source, weight, found := strings.Cut(w, ":")
+		if !found {
+			return nil, errors.Errorf("invalid PRICE_BLENDING_WEIGHTS %q, expected source:weight", w)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil || f < 0 {
+			return nil, errors.Errorf("invalid PRICE_BLENDING_WEIGHTS %q, weight must be a positive number", w)
+		}
+		parsed[strings.ToLower(strings.TrimSpace(source))] = f
+	}
+	return parsed, nil
+}
+
+type vendorBlending struct{}
+
+func (vendorBlending) Name() string { return VendorBlending }
+
+func (vendorBlending) Blend(input BlendInput) float64 {
+	return input.VendorPrice
+}
+
+type meanBlending struct{}
+
+func (meanBlending) Name() string { return MeanBlending }
+
+func (meanBlending) Blend(input BlendInput) float64 {
+	if len(input.Points) == 0 {
+		return input.VendorPrice
+	}
+	return mean(input.Points)
+}
+
+type medianBlending struct{}
+
+func (medianBlending) Name() string { return MedianBlending }
+
+func (medianBlending) Blend(input BlendInput) float64 {
+	if len(input.Points) == 0 {
+		return input.VendorPrice
+	}
+	values := sortedValues(input.Points)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+type weightedBlending struct {
+	weights map[string]float64
+}
+
+func (weightedBlending) Name() string { return WeightedBlending }
+
+func (w weightedBlending) Blend(input BlendInput) float64 {
+	sum, total := 0.0, 0.0
+	for _, p := range input.Points {
+		weight, ok := w.weights[strings.ToLower(p.Source)]
+		if !ok {
+			weight = 1
+		}
+		sum += p.Value * weight
+		total += weight
+	}
+	if total == 0 {
+		return input.VendorPrice
+	}
+	return sum / total
+}
+
+type trimmedBlending struct {
+	percent float64
+}
+
+func (trimmedBlending) Name() string { return TrimmedBlending }
+
+func (t trimmedBlending) Blend(input BlendInput) float64 {
+	if len(input.Points) == 0 {
+		return input.VendorPrice
+	}
+	values := sortedValues(input.Points)
+	trim := int(float64(len(values)) * t.percent / 100)
+	values = values[trim : len(values)-trim]
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+type stdevBlending struct {
+	limit float64
+}
+
+func (stdevBlending) Name() string { return StdevBlending }
+
+// Blend mean of the points within the limit of standard deviations from the mean, using the vendor reported deviation
+// when there is one
+func (s stdevBlending) Blend(input BlendInput) float64 {
+	if len(input.Points) == 0 {
+		return input.VendorPrice
+	}
+	m := mean(input.Points)
+	stdev := input.Stdev
+	if stdev <= 0 {
+		for _, p := range input.Points {
+			stdev += (p.Value - m) * (p.Value - m)
+		}
+		stdev = math.Sqrt(stdev / float64(len(input.Points)))
+	}
+	var kept []PricePoint
+	for _, p := range input.Points {
+		if math.Abs(p.Value-m) <= s.limit*stdev {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 {
+		return m
+	}
+	return mean(kept)
+}
+
+func mean(points []PricePoint) float64 {
+	sum := 0.0
+	for _, p := range points {
+		sum += p.Value
+	}
+	return sum / float64(len(points))
+}
+
+func sortedValues(points []PricePoint) []float64 {
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+	sort.Float64s(values)
+	return values
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/valuations-api/internal/config"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PriceBlending_strategies(t *testing.T) {
+	blending, err := NewPriceBlending(&config.Settings{
+		PriceBlendingRouting:     "USA:mean;CAN:median;MEX:weighted;DEU:trimmed;FRA:stdev",
+		PriceBlendingWeights:     "blackbook:3",
+		PriceBlendingTrimPercent: 20,
+		PriceBlendingStdevLimit:  1,
+	})
+	require.NoError(t, err)
+	input := BlendInput{
+		VendorPrice: 15000,
+		Points: []PricePoint{
+			{Source: "blackbook", Value: 10000},
+			{Source: "kbb", Value: 11000},
+			{Source: "edmunds", Value: 12000},
+			{Source: "nada", Value: 13000},
+			{Source: "cargurus", Value: 30000},
+		},
+	}
+
+	tests := []struct {
+		country  string
+		strategy string
+		want     float64
+	}{
+		{country: "BRA", strategy: VendorBlending, want: 15000},
+		{country: "US", strategy: MeanBlending, want: 15200},
+		{country: "CAN", strategy: MedianBlending, want: 12000},
+		// blackbook weighs 3, the rest 1: (30000 + 66000) / 7
+		{country: "MEX", strategy: WeightedBlending, want: 96000.0 / 7},
+		// one point trimmed from each end
+		{country: "DEU", strategy: TrimmedBlending, want: 12000},
+		// cargurus is more than a standard deviation from the mean
+		{country: "FRA", strategy: StdevBlending, want: 11500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			strategy := blending.ForCountry(tt.country)
+			assert.Equal(t, tt.strategy, strategy.Name())
+			assert.InDelta(t, tt.want, strategy.Blend(input), 0.01)
+			assert.Equal(t, 15000.0, strategy.Blend(BlendInput{VendorPrice: 15000}), "no points uses the vendor price")
+		})
+	}
+}
+
+func Test_PriceBlending_vendorStdev(t *testing.T) {
+	input := BlendInput{
+		Points: []PricePoint{{Value: 100}, {Value: 200}, {Value: 300}},
+		Stdev:  50,
+	}
+
+	assert.Equal(t, 200.0, stdevBlending{limit: 1}.Blend(input), "only the mean is within the vendor stdev")
+}
+
+func Test_PriceBlending_nil(t *testing.T) {
+	var blending *PriceBlending
+
+	assert.Equal(t, VendorBlending, blending.ForCountry("USA").Name())
+}
+
+func Test_NewPriceBlending_invalid(t *testing.T) {
+	for _, settings := range []config.Settings{
+		{PriceBlendingRouting: "USA:average"},
+		{PriceBlendingRouting: "USA:mean,median"},
+		{PriceBlendingWeights: "kbb"},
+		{PriceBlendingWeights: "kbb:-1"},
+		{PriceBlendingTrimPercent: 50},
+	} {
+		_, err := NewPriceBlending(&settings)
+		assert.Error(t, err, "%+v", settings)
+	}
+}
+
+func Test_vincarioValuationService_ProjectValuation_blending(t *testing.T) {
+	blending, err := NewPriceBlending(&config.Settings{PriceBlendingRouting: "DEU:median"})
+	require.NoError(t, err)
+	valuation := setupCreateValuationsData(t, 123, ksuid.New().String(), "vinny", map[string][]byte{
+		"VincarioMetadata": []byte(testVincarioValuationJSON),
+	}, nil)
+
+	valSet := (&vincarioValuationService{blending: blending}).ProjectValuation(valuation, "DE")
+
+	require.NotNil(t, valSet)
+	assert.Equal(t, MedianBlending, valSet.BlendingStrategy)
+	assert.Equal(t, 31990, valSet.UserDisplayPrice, "median of the listings, not the vincario average")
+}
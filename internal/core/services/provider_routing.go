@@ -0,0 +1,62 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultRoute is the routing key used for any country not explicitly listed
+const DefaultRoute = "*"
+
+// ProviderRouting maps ISO 3166-1 alpha-3 country codes to the ordered list of provider names to try for that country.
+type ProviderRouting map[string][]string
+
+// ParseProviderRouting parses a routing table from settings. Routes are separated by `;`, each route is a comma separated
+// list of countries (alpha-2 or alpha-3, or * for any other country), a colon, and the comma separated providers in order
+// of preference, eg: `USA,CAN:drivly;DEU,FRA:vincario,drivly;*:vincario`. Returns nil routing for an empty string.
+func ParseProviderRouting(routing string) (ProviderRouting, error) {
+	if strings.TrimSpace(routing) == "" {
+		return nil, nil
+	}
+	pr := ProviderRouting{}
+	for _, route := range strings.Split(routing, ";") {
+		if strings.TrimSpace(route) == "" {
+			continue
+		}
+		countries, providers, found := strings.Cut(route, ":")
+		if !found {
+			return nil, errors.Errorf("invalid provider route %q, expected countries:providers", route)
+		}
+		var names []string
+		for _, p := range strings.Split(providers, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				names = append(names, strings.ToLower(p))
+			}
+		}
+		if len(names) == 0 {
+			return nil, errors.Errorf("invalid provider route %q, no providers", route)
+		}
+		for _, c := range strings.Split(countries, ",") {
+			c = strings.TrimSpace(c)
+			if c != DefaultRoute {
+				alpha3 := ConvertCountryToAlpha3(c)
+				if alpha3 == "" {
+					return nil, errors.Errorf("invalid provider route %q, unknown country code %q", route, c)
+				}
+				c = alpha3
+			}
+			pr[c] = names
+		}
+	}
+	return pr, nil
+}
+
+// Route returns the provider names for the alpha-3 country code, falling back to the default route. False if neither is set.
+func (pr ProviderRouting) Route(countryCode string) ([]string, bool) {
+	if names, ok := pr[countryCode]; ok {
+		return names, true
+	}
+	names, ok := pr[DefaultRoute]
+	return names, ok
+}
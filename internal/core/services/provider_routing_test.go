@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProviderRouting(t *testing.T) {
+	pr, err := ParseProviderRouting(" USA, ca : Drivly ; DEU:vincario,drivly;*:vincario;")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"drivly"}, pr["USA"])
+	assert.Equal(t, []string{"drivly"}, pr["CAN"], "alpha-2 codes are converted to alpha-3")
+	assert.Equal(t, []string{"vincario", "drivly"}, pr["DEU"])
+
+	route, ok := pr.Route("FRA")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"vincario"}, route, "falls back to default route")
+}
+
+func TestParseProviderRouting_empty(t *testing.T) {
+	pr, err := ParseProviderRouting("")
+	require.NoError(t, err)
+	assert.Nil(t, pr)
+
+	_, ok := pr.Route("USA")
+	assert.False(t, ok)
+}
+
+func TestParseProviderRouting_invalid(t *testing.T) {
+	_, err := ParseProviderRouting("USA drivly")
+	assert.Error(t, err)
+	_, err = ParseProviderRouting("XXX:drivly")
+	assert.Error(t, err)
+	_, err = ParseProviderRouting("USA:")
+	assert.Error(t, err)
+}
+
+func TestConvertCountry(t *testing.T) {
+	assert.Equal(t, "USA", ConvertCountryToAlpha3("US"))
+	assert.Equal(t, "TUR", ConvertCountryToAlpha3("tr"))
+	assert.Equal(t, "DEU", ConvertCountryToAlpha3("DEU"))
+	assert.Equal(t, "", ConvertCountryToAlpha3("XX"))
+	assert.Equal(t, "", ConvertCountryToAlpha3(""))
+
+	assert.Equal(t, "GB", ConvertCountryToAlpha2("GBR"))
+	assert.Equal(t, "CA", ConvertCountryToAlpha2("ca"))
+	assert.Equal(t, "", ConvertCountryToAlpha2("XXX"))
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/shared/pkg/db"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// revaluationLockID postgres advisory lock key, held by the replica running the scheduled revaluation
+const revaluationLockID int64 = 8210374419
+
+// RevaluationScheduler periodically re-pulls valuations that are past their provider repull window. Every replica runs the
+// scheduler, a postgres advisory lock makes sure only one of them pulls at a time.
+type RevaluationScheduler struct {
+	dbs         func() *db.ReaderWriter
+	log         *zerolog.Logger
+	batchSvc    *BatchValuationService
+	interval    time.Duration
+	concurrency int
+}
+
+func NewRevaluationScheduler(dbs func() *db.ReaderWriter, log *zerolog.Logger, batchSvc *BatchValuationService,
+	interval time.Duration, concurrency int) *RevaluationScheduler {
+	return &RevaluationScheduler{
+		dbs:         dbs,
+		log:         log,
+		batchSvc:    batchSvc,
+		interval:    interval,
+		concurrency: concurrency,
+	}
+}
+
+// Start runs the revaluation every interval until the context is cancelled, blocks
+func (r *RevaluationScheduler) Start(ctx context.Context) {
+	r.log.Info().Msgf("scheduled revaluation every %s", r.interval)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.RunOnce(ctx); err != nil {
+				r.log.Err(err).Msg("scheduled revaluation failed")
+			}
+		}
+	}
+}
+
+// RunOnce pulls the valuations that are due if no other replica is already doing it. Returns false if the lock was held
+// elsewhere.
+func (r *RevaluationScheduler) RunOnce(ctx context.Context) (bool, error) {
+	// advisory locks belong to the session, so lock and unlock on the same connection
+	conn, err := r.dbs().Writer.Conn(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get db connection")
+	}
+	defer conn.Close() //nolint:errcheck
+
+	locked := false
+	if err := conn.QueryRowContext(ctx, "select pg_try_advisory_lock($1)", revaluationLockID).Scan(&locked); err != nil {
+		return false, errors.Wrap(err, "failed to get revaluation lock")
+	}
+	if !locked {
+		r.log.Debug().Msg("revaluation already running on another replica, skipping")
+		return false, nil
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "select pg_advisory_unlock($1)", revaluationLockID); err != nil {
+			r.log.Err(err).Msg("failed to release revaluation lock")
+		}
+	}()
+
+	start := time.Now()
+	summary, err := r.batchSvc.PullValuations(ctx, BatchPullOptions{Concurrency: r.concurrency})
+	if err != nil {
+		return true, err
+	}
+	r.log.Info().Int("vehicles", summary.Vehicles).Int("pulled", summary.Pulled).Int("skipped", summary.Skipped).
+		Int("no_provider", summary.NoProvider).Int("errored", summary.Errored).
+		Msgf("scheduled revaluation completed in %s", time.Since(start))
+	return true, nil
+}
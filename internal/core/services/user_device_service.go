@@ -5,8 +5,6 @@ import (
 	"database/sql"
 	"math/big"
 	"sort"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
@@ -16,6 +14,7 @@ import (
 
 	"github.com/DIMO-Network/shared/pkg/db"
 	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/core/units"
 	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -24,10 +23,22 @@ import (
 	"google.golang.org/grpc"
 )
 
+// OfferValidity how long instant offers from vendors are good for
+const OfferValidity = time.Hour * 24 * 7
+
+// page size bounds for the valuation history
+const (
+	DefaultValuationHistoryLimit = 50
+	MaxValuationHistoryLimit     = 200
+)
+
 //go:generate mockgen -source user_device_service.go -destination mocks/user_device_service_mock.go
 type UserDeviceAPIService interface {
 	GetOffers(ctx context.Context, tokenID uint64) (*core.DeviceOffer, error)
-	GetValuations(ctx context.Context, tokenID uint64, privJWT string) (*core.DeviceValuation, error)
+	// GetValuations latest valuation for the vehicle, converted to currency if not empty. privJWT can be empty for internal
+	// callers, then only the stored vehicle location is used.
+	GetValuations(ctx context.Context, tokenID uint64, privJWT, currency string) (*core.DeviceValuation, error)
+	GetValuationHistory(ctx context.Context, tokenID uint64, privJWT string, query core.ValuationHistoryQuery) (*core.ValuationHistory, error)
 	CanRequestInstantOffer(ctx context.Context, tokenID uint64) (bool, error)
 	LastRequestDidGiveError(ctx context.Context, tokenID uint64) (bool, error)
 }
@@ -38,16 +49,20 @@ type userDeviceAPIService struct {
 	logger       *zerolog.Logger
 	locationSvc  LocationService
 	telemetryAPI gateways.TelemetryAPI
+	providers    *ValuationProviderRegistry
+	fx           FxRateService
 }
 
 func NewUserDeviceService(devicesConn *grpc.ClientConn, dbs func() *db.ReaderWriter, logger *zerolog.Logger,
-	locationSvc LocationService, telemetryAPI gateways.TelemetryAPI) UserDeviceAPIService {
+	locationSvc LocationService, telemetryAPI gateways.TelemetryAPI, providers *ValuationProviderRegistry, fx FxRateService) UserDeviceAPIService {
 	return &userDeviceAPIService{
 		devicesConn:  devicesConn,
 		dbs:          dbs,
 		logger:       logger,
 		locationSvc:  locationSvc,
 		telemetryAPI: telemetryAPI,
+		providers:    providers,
+		fx:           fx,
 	}
 }
 
@@ -57,47 +72,122 @@ func (das *userDeviceAPIService) GetOffers(ctx context.Context, tokenID uint64)
 	drivlyVinData, err := models.Valuations(
 		models.ValuationWhere.TokenID.EQ(tokenDecimal),
 		models.ValuationWhere.OfferMetadata.IsNotNull(), // offer_metadata is sourced from drivly
-		qm.OrderBy("updated_at desc"),
-		qm.Limit(1)).All(ctx, das.dbs().Reader)
+		qm.OrderBy("updated_at desc")).All(ctx, das.dbs().Reader)
 
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
 
-	offers, err := getUserDeviceOffers(drivlyVinData)
+	offers, err := getUserDeviceOffers(drivlyVinData, time.Now())
 	return offers, err
 }
 
 // GetValuations retrieves device valuation details based on the provided tokenID and private JWT token header include Bearer JWT.
 // It queries valuation data, retrieves the latest telemetry signals, and determines the geo-decoded location for valuation.
-func (das *userDeviceAPIService) GetValuations(ctx context.Context, tokenID uint64, privJWT string) (*core.DeviceValuation, error) {
+// If currency is set the prices are converted at the rate effective when the valuation was pulled.
+func (das *userDeviceAPIService) GetValuations(ctx context.Context, tokenID uint64, privJWT, currency string) (*core.DeviceValuation, error) {
 	d := decimal.New(int64(tokenID), 0)
 	valuationData, err := models.Valuations(
 		models.ValuationWhere.TokenID.EQ(types.NewNullDecimal(d)),
-		qm.Where("drivly_pricing_metadata is not null or vincario_metadata is not null"),
+		das.providers.HasValuationData(),
 		qm.OrderBy("updated_at desc"),
 		qm.Limit(1)).All(ctx, das.dbs().Reader)
 
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return nil, err
 	}
-	signals, err := das.telemetryAPI.GetLatestSignals(tokenID, privJWT)
-	if err != nil {
-		das.logger.Error().Err(err).Msgf("failed to get latest signals for token %d, skipping", tokenID)
+	countryCode := das.valuationCountryCode(ctx, tokenID, privJWT)
+
+	if currency == "" {
+		return buildValuationsFromSlice(das.logger, das.providers, valuationData, countryCode, nil)
+	}
+	return buildValuationsFromSlice(das.logger, das.providers, valuationData, countryCode,
+		func(valSet *core.ValuationSet, valuation *models.Valuation) error {
+			return das.fx.ConvertValuationSet(ctx, valSet, currency, valuation.CreatedAt)
+		})
+}
+
+// GetValuationHistory retrieves every valuation pulled for the vehicle, newest first, filtered by the query's created at
+// range and paged with the cursor returned in the previous page.
+func (das *userDeviceAPIService) GetValuationHistory(ctx context.Context, tokenID uint64, privJWT string, query core.ValuationHistoryQuery) (*core.ValuationHistory, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultValuationHistoryLimit
+	}
+	if limit > MaxValuationHistoryLimit {
+		limit = MaxValuationHistoryLimit
+	}
+	d := decimal.New(int64(tokenID), 0)
+	mods := []qm.QueryMod{
+		models.ValuationWhere.TokenID.EQ(types.NewNullDecimal(d)),
+		das.providers.HasValuationData(),
+	}
+	if !query.From.IsZero() {
+		mods = append(mods, models.ValuationWhere.CreatedAt.GTE(query.From))
+	}
+	if !query.To.IsZero() {
+		mods = append(mods, models.ValuationWhere.CreatedAt.LT(query.To))
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeHistoryCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		mods = append(mods, qm.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID))
+	}
+	// get one extra to know if there is a next page
+	mods = append(mods, qm.OrderBy("created_at desc, id desc"), qm.Limit(limit+1))
+
+	valuationData, err := models.Valuations(mods...).All(ctx, das.dbs().Reader)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	hasMore := len(valuationData) > limit
+	if hasMore {
+		valuationData = valuationData[:limit]
+	}
+	countryCode := das.valuationCountryCode(ctx, tokenID, privJWT)
+
+	history := core.ValuationHistory{
+		ValuationSets: []core.ValuationSet{},
+	}
+	for _, valuation := range valuationData {
+		valSet := das.providers.ProjectValuation(das.logger, valuation, countryCode)
+		if valSet != nil {
+			history.ValuationSets = append(history.ValuationSets, *valSet)
+		}
+	}
+	if hasMore {
+		last := valuationData[len(valuationData)-1]
+		history.NextCursor = encodeHistoryCursor(last.CreatedAt, last.ID)
+	}
+
+	return &history, nil
+}
+
+// valuationCountryCode alpha-3 country the vehicle is in, used when projecting valuations. Defaults to USA if the
+// location can't be determined.
+func (das *userDeviceAPIService) valuationCountryCode(ctx context.Context, tokenID uint64, privJWT string) string {
+	var signals *core.SignalsLatest
+	// telemetry needs the privilege token, without it we can only use the location we already decoded
+	if privJWT != "" {
+		var err error
+		signals, err = das.telemetryAPI.GetLatestSignals(ctx, tokenID, privJWT)
+		if err != nil {
+			das.logger.Error().Err(err).Msgf("failed to get latest signals for token %d, skipping", tokenID)
+		}
 	}
 	location, err := das.locationSvc.GetGeoDecodedLocation(ctx, signals, tokenID)
 	if err != nil {
 		das.logger.Error().Err(err).Msgf("failed to get geo decoded location for token %d, skipping", tokenID)
 	}
-	countryCode := "USA"
 	if location != nil {
-		countryCode = location.CountryCode
+		return ConvertCountryToAlpha3(location.CountryCode)
 	}
-
-	return buildValuationsFromSlice(das.logger, valuationData, countryCode)
+	return "USA"
 }
 
-func getUserDeviceOffers(drivlyVinData models.ValuationSlice) (*core.DeviceOffer, error) {
+func getUserDeviceOffers(drivlyVinData models.ValuationSlice, now time.Time) (*core.DeviceOffer, error) {
 	dOffer := core.DeviceOffer{
 		OfferSets: []core.OfferSet{},
 	}
@@ -107,27 +197,23 @@ func getUserDeviceOffers(drivlyVinData models.ValuationSlice) (*core.DeviceOffer
 
 		requestJSON := offer.RequestMetadata.JSON
 		offerSet.Updated = offer.UpdatedAt.Format(time.RFC3339)
-		// remove offer url if it has expired (7 days)
-		if offer.UpdatedAt.Add(time.Hour * 24 * 7).Before(time.Now()) {
-			for i := range offerSet.Offers {
-				offerSet.Offers[i].URL = ""
-			}
-		}
+		expiresAt := offer.UpdatedAt.Add(OfferValidity)
+		offerSet.ExpiresAt = expiresAt.Format(time.RFC3339)
+		offerSet.Expired = !now.Before(expiresAt)
 
 		requestMileage := gjson.GetBytes(requestJSON, "mileage")
 		if requestMileage.Exists() {
 			offerSet.Mileage = int(requestMileage.Int())
 		}
+		// offers are requested from drivly in miles
+		offerSet.OdometerUnit = units.Miles.String()
 		requestZipCode := gjson.GetBytes(requestJSON, "zipCode")
 		if requestZipCode.Exists() {
 			offerSet.ZipCode = requestZipCode.String()
 		}
-		// set odometer measure type
-		if offerSet.Mileage%12000 == 0 {
-			offerSet.OdometerMeasurementType = core.Estimated
-		} else {
-			offerSet.OdometerMeasurementType = core.Real
-		}
+		// offers from before the mileage source was recorded don't have the request, their mileage is unknown
+		mileageSource := core.MileageSource(gjson.GetBytes(requestJSON, "mileageSource").String())
+		offerSet.OdometerMeasurementType = mileageSource.OdometerMeasurementType()
 
 		dOffer.OfferSets = append(dOffer.OfferSets, offerSet)
 	}
@@ -139,16 +225,24 @@ func getUserDeviceOffers(drivlyVinData models.ValuationSlice) (*core.DeviceOffer
 	return &dOffer, nil
 }
 
-func buildValuationsFromSlice(logger *zerolog.Logger, valuations models.ValuationSlice, countryCode string) (*core.DeviceValuation, error) {
+// buildValuationsFromSlice projects each valuation with its provider, convert is applied to each projected set if not nil
+func buildValuationsFromSlice(logger *zerolog.Logger, providers *ValuationProviderRegistry, valuations models.ValuationSlice, countryCode string,
+	convert func(valSet *core.ValuationSet, valuation *models.Valuation) error) (*core.DeviceValuation, error) {
 	dVal := core.DeviceValuation{
 		ValuationSets: []core.ValuationSet{},
 	}
 
 	for _, valuation := range valuations {
-		valSet := projectValuation(logger, valuation, countryCode)
-		if valSet != nil {
-			dVal.ValuationSets = append(dVal.ValuationSets, *valSet)
+		valSet := providers.ProjectValuation(logger, valuation, countryCode)
+		if valSet == nil {
+			continue
 		}
+		if convert != nil {
+			if err := convert(valSet, valuation); err != nil {
+				return nil, err
+			}
+		}
+		dVal.ValuationSets = append(dVal.ValuationSets, *valSet)
 	}
 	sort.Slice(dVal.ValuationSets, func(i, j int) bool {
 		return dVal.ValuationSets[i].Updated > dVal.ValuationSets[j].Updated
@@ -157,102 +251,6 @@ func buildValuationsFromSlice(logger *zerolog.Logger, valuations models.Valuatio
 	return &dVal, nil
 }
 
-func projectValuation(logger *zerolog.Logger, valuation *models.Valuation, countryCode string) *core.ValuationSet {
-	if !valuation.DrivlyPricingMetadata.Valid && !valuation.VincarioMetadata.Valid {
-		return nil
-	}
-	valSet := core.ValuationSet{
-		Updated: valuation.UpdatedAt.Format(time.RFC3339),
-	}
-	if valuation.DrivlyPricingMetadata.Valid {
-		valSet.Vendor = "drivly"
-		valSet.TradeInSource = "drivly"
-		valSet.RetailSource = "drivly"
-
-		drivlyJSON := valuation.DrivlyPricingMetadata.JSON
-		requestJSON := valuation.RequestMetadata.JSON
-		drivlyMileage := gjson.GetBytes(drivlyJSON, "mileage")
-		if drivlyMileage.Exists() {
-			valSet.Mileage = int(drivlyMileage.Int())
-			valSet.Odometer = int(drivlyMileage.Int())
-			valSet.OdometerUnit = "miles"
-		} else {
-			requestMileage := gjson.GetBytes(requestJSON, "mileage")
-			if requestMileage.Exists() {
-				valSet.Mileage = int(requestMileage.Int())
-			}
-		}
-		requestZipCode := gjson.GetBytes(requestJSON, "zipCode")
-		if requestZipCode.Exists() {
-			valSet.ZipCode = requestZipCode.String()
-		}
-		// Drivly Trade-In
-		valSet.TradeIn = extractDrivlyValuation(drivlyJSON, "trade")
-		valSet.TradeInAverage = valSet.TradeIn
-		// Drivly Retail
-		valSet.Retail = extractDrivlyValuation(drivlyJSON, "retail")
-		valSet.RetailAverage = valSet.Retail
-		valSet.Currency = "USD"
-
-		// set the price to display to users
-		valSet.UserDisplayPrice = (valSet.Retail + valSet.TradeIn) / 2
-	} else if valuation.VincarioMetadata.Valid {
-		ratio := 1.0
-		if strings.EqualFold(countryCode, "TUR") {
-			ratio = 1.5
-		}
-		valSet.Vendor = "vincario"
-		valSet.TradeInSource = "vincario"
-		valSet.RetailSource = "vincario"
-
-		valJSON := valuation.VincarioMetadata.JSON
-		requestJSON := valuation.RequestMetadata.JSON
-		// vincario now suports two markets
-		odometerRegion := gjson.GetBytes(valJSON, "market_odometer.europe")
-		if !odometerRegion.Exists() {
-			odometerRegion = gjson.GetBytes(valJSON, "market_odometer.north_america")
-		}
-		odometerMarket := odometerRegion.Get("odometer_avg")
-
-		if odometerMarket.Exists() {
-			valSet.Mileage = int(odometerMarket.Int())
-			valSet.Odometer = int(odometerMarket.Int())
-			valSet.OdometerUnit = odometerRegion.Get("odometer_unit").String()
-		}
-		// TODO: this needs to be implemented in the load_valuations script
-		requestPostalCode := gjson.GetBytes(requestJSON, "postalCode")
-		if requestPostalCode.Exists() {
-			valSet.ZipCode = requestPostalCode.String()
-		}
-		priceRegion := gjson.GetBytes(valJSON, "market_price.europe")
-		if !priceRegion.Exists() {
-			priceRegion = gjson.GetBytes(valJSON, "market_price.north_america")
-		}
-		// vincario Trade-In - just using the price below mkt mean
-		valSet.TradeIn = int(priceRegion.Get("price_below").Float() * ratio)
-		valSet.TradeInAverage = valSet.TradeIn
-		// vincario Retail - just using the price above mkt mean
-		valSet.Retail = int(priceRegion.Get("price_above").Float() * ratio)
-		valSet.RetailAverage = valSet.Retail
-
-		valSet.UserDisplayPrice = int(priceRegion.Get("price_avg").Float() * ratio)
-		valSet.Currency = priceRegion.Get("price_currency").String()
-	}
-	// make sure valid data & set odo type
-	if valSet.Retail > 0 || valSet.TradeIn > 0 {
-		if valSet.Vendor == "vincario" {
-			valSet.OdometerMeasurementType = core.Market
-		} else if valSet.Odometer%12000 == 0 {
-			valSet.OdometerMeasurementType = core.Estimated
-		} else {
-			valSet.OdometerMeasurementType = core.Real
-		}
-		return &valSet
-	}
-	logger.Debug().Str("vin", valuation.Vin).Msgf("did not find a market value from %s, or valJSON in unexpected format", valSet.Vendor)
-	return nil
-}
-
 func (das *userDeviceAPIService) CanRequestInstantOffer(ctx context.Context, tokenID uint64) (bool, error) {
 	tokenDecimal := types.NewNullDecimal(decimal.New(int64(tokenID), 0))
 	existingOfferData, err := models.Valuations(
@@ -343,45 +341,3 @@ func lastRequestDidGiveError(existingOfferData *models.Valuation) (bool, error)
 
 	return true, nil
 }
-
-// extractDrivlyValuation pulls out the price from the drivly json, based on the passed in key, eg. trade or retail. calculates average if no root property found
-func extractDrivlyValuation(drivlyJSON []byte, key string) int {
-	// handle when value is just set at top level
-	if gjson.GetBytes(drivlyJSON, key).Exists() && !gjson.GetBytes(drivlyJSON, key).IsObject() {
-		v := gjson.GetBytes(drivlyJSON, key).String()
-		vf, _ := strconv.ParseFloat(v, 64)
-		return int(vf)
-	}
-	// if no specific value, make an average of all values drivly offers
-	pricings := map[string]int64{}
-	if gjson.GetBytes(drivlyJSON, key+".blackBook.totalAvg").Exists() {
-		pricings["blackbook"] = gjson.GetBytes(drivlyJSON, key+".blackBook.totalAvg").Int()
-	}
-	if gjson.GetBytes(drivlyJSON, key+".kelley.good").Exists() {
-		pricings["kbb"] = gjson.GetBytes(drivlyJSON, key+".kelley.good").Int()
-	} else if gjson.GetBytes(drivlyJSON, key+".kelley.book").Exists() {
-		pricings["kbb"] = gjson.GetBytes(drivlyJSON, key+".kelley.book").Int()
-	}
-	if gjson.GetBytes(drivlyJSON, key+".edmunds.average").Exists() {
-		pricings["edmunds"] = gjson.GetBytes(drivlyJSON, key+".edmunds.average").Int()
-	}
-	if gjson.GetBytes(drivlyJSON, key+".nada.book").Exists() {
-		pricings["nada"] = gjson.GetBytes(drivlyJSON, key+".nada.book").Int()
-	}
-	if gjson.GetBytes(drivlyJSON, key+".cargurus").Exists() {
-		pricings["cargurus"] = gjson.GetBytes(drivlyJSON, key+".cargurus").Int()
-	}
-	if len(pricings) > 0 {
-		sum := int64(0)
-		denominator := int64(0)
-		for _, v := range pricings {
-			if v > 100 {
-				sum += v
-				denominator++
-			}
-		}
-		return int(sum / denominator)
-	}
-
-	return 0
-}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	mock_gateways "github.com/DIMO-Network/valuations-api/internal/core/gateways/mocks"
 	mock_services "github.com/DIMO-Network/valuations-api/internal/core/services/mocks"
@@ -38,6 +39,7 @@ type UserDeviceServiceTestSuite struct {
 	svc         UserDeviceAPIService
 	locationSvc *mock_services.MockLocationService
 	telemetry   *mock_gateways.MockTelemetryAPI
+	fx          *mock_services.MockFxRateService
 }
 
 //go:embed test_drivly_offers_by_vin.json
@@ -63,8 +65,9 @@ func (s *UserDeviceServiceTestSuite) SetupSuite() {
 	mockCtrl := gomock.NewController(s.T())
 	s.locationSvc = mock_services.NewMockLocationService(mockCtrl)
 	s.telemetry = mock_gateways.NewMockTelemetryAPI(mockCtrl)
+	s.fx = mock_services.NewMockFxRateService(mockCtrl)
 
-	s.svc = NewUserDeviceService(nil, s.pdb.DBS, logger, s.locationSvc, s.telemetry)
+	s.svc = NewUserDeviceService(nil, s.pdb.DBS, logger, s.locationSvc, s.telemetry, testProviders(), s.fx)
 }
 
 func (s *UserDeviceServiceTestSuite) SetupTest() {
@@ -106,7 +109,7 @@ func Test_projectValuation(t *testing.T) {
 		"DrivlyPricingMetadata": []byte(testDrivlyValuations3JSON),
 	}, nil)
 
-	valuationSet := projectValuation(&logger, valuation, "USA")
+	valuationSet := testProviders().ProjectValuation(&logger, valuation, "USA")
 
 	// mileage comes from request metadata, but it is also sometimes returned by payload
 	assert.Equal(t, 24000, valuationSet.Mileage, "mileage must be what is in the mileage json node from drivly, ideally matches request")
@@ -132,7 +135,7 @@ func Test_projectValuation_empty(t *testing.T) {
 		TokenID:       types.NewNullDecimal(new(decimal.Big).SetUint64(tokenID)),
 		OfferMetadata: null.JSONFrom([]byte(`{}`)),
 	}
-	val := projectValuation(&logger, &v, "USA")
+	val := testProviders().ProjectValuation(&logger, &v, "USA")
 	assert.Nil(t, val, "if no valuations should return nil")
 }
 
@@ -150,13 +153,13 @@ func (s *UserDeviceServiceTestSuite) TestGetUserDeviceValuations_Format1() {
 		CurrentLocationLatitude:                 core.TimeFloatValue{Value: 49.241},
 		CurrentLocationLongitude:                core.TimeFloatValue{Value: -123.521},
 	}
-	s.telemetry.EXPECT().GetLatestSignals(tokenID, "caca").Return(&signals, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, "caca").Return(&signals, nil)
 	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), &signals, tokenID).Return(&core.LocationResponse{
 		CountryCode: "USA",
 	}, nil)
 
 	// test
-	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca")
+	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca", "")
 
 	assert.NoError(s.T(), err)
 
@@ -187,13 +190,13 @@ func (s *UserDeviceServiceTestSuite) TestGetValuations_setsTokenIDFromUDID() {
 		CurrentLocationLatitude:                 core.TimeFloatValue{Value: 49.241},
 		CurrentLocationLongitude:                core.TimeFloatValue{Value: -123.521},
 	}
-	s.telemetry.EXPECT().GetLatestSignals(tokenID, "caca").Return(&signals, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, "caca").Return(&signals, nil)
 	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), &signals, tokenID).Return(&core.LocationResponse{
 		CountryCode: "USA",
 	}, nil)
 
 	// tokenId not being set
-	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca")
+	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca", "")
 
 	assert.NoError(s.T(), err)
 
@@ -225,12 +228,12 @@ func (s *UserDeviceServiceTestSuite) TestGetUserDeviceValuations_Format2() {
 	_ = setupCreateValuationsData(s.T(), tokenID, ddID, vin, map[string][]byte{
 		"DrivlyPricingMetadata": []byte(testDrivlyPricing2JSON),
 	}, &s.pdb)
-	s.telemetry.EXPECT().GetLatestSignals(tokenID, "caca").Return(nil, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, "caca").Return(nil, nil)
 	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), nil, tokenID).Return(&core.LocationResponse{
 		CountryCode: "USA",
 	}, nil)
 
-	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca")
+	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca", "")
 
 	assert.NoError(s.T(), err)
 	assert.Equal(s.T(), 1, len(valuations.ValuationSets))
@@ -253,12 +256,12 @@ func (s *UserDeviceServiceTestSuite) TestGetUserDeviceValuations_Vincario() {
 		CurrentLocationLatitude:                 core.TimeFloatValue{Value: 49.241},
 		CurrentLocationLongitude:                core.TimeFloatValue{Value: -123.521},
 	}
-	s.telemetry.EXPECT().GetLatestSignals(tokenID, "caca").Return(&signals, nil)
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, "caca").Return(&signals, nil)
 	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), &signals, tokenID).Return(&core.LocationResponse{
 		CountryCode: "USA",
 	}, nil)
 
-	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca")
+	valuations, err := s.svc.GetValuations(s.ctx, tokenID, "caca", "")
 
 	assert.NoError(s.T(), err)
 	assert.Equal(s.T(), 1, len(valuations.ValuationSets))
@@ -275,6 +278,41 @@ func (s *UserDeviceServiceTestSuite) TestGetUserDeviceValuations_Vincario() {
 
 // *** Instant Offers (USA only) *** //
 
+func (s *UserDeviceServiceTestSuite) TestGetValuationHistory_paging() {
+	tokenID := uint64(12335)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		val := setupCreateValuationsData(s.T(), tokenID, ksuid.New().String(), "vinny", map[string][]byte{
+			"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+		}, &s.pdb)
+		val.CreatedAt = start.AddDate(0, i, 0)
+		_, err := val.Update(s.ctx, s.pdb.DBS().Writer, boil.Whitelist(models.ValuationColumns.CreatedAt))
+		require.NoError(s.T(), err)
+	}
+	s.telemetry.EXPECT().GetLatestSignals(gomock.Any(), tokenID, "caca").Return(nil, nil).Times(3)
+	s.locationSvc.EXPECT().GetGeoDecodedLocation(gomock.Any(), nil, tokenID).Return(&core.LocationResponse{
+		CountryCode: "US",
+	}, nil).Times(3)
+
+	page1, err := s.svc.GetValuationHistory(s.ctx, tokenID, "caca", core.ValuationHistoryQuery{Limit: 2})
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), page1.ValuationSets, 2)
+	require.NotEmpty(s.T(), page1.NextCursor)
+
+	page2, err := s.svc.GetValuationHistory(s.ctx, tokenID, "caca", core.ValuationHistoryQuery{Limit: 2, Cursor: page1.NextCursor})
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), page2.ValuationSets, 1)
+	assert.Empty(s.T(), page2.NextCursor)
+
+	// only the february valuation
+	filtered, err := s.svc.GetValuationHistory(s.ctx, tokenID, "caca", core.ValuationHistoryQuery{
+		From: start.AddDate(0, 1, 0),
+		To:   start.AddDate(0, 2, 0),
+	})
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), filtered.ValuationSets, 1)
+}
+
 func (s *UserDeviceServiceTestSuite) TestGetUserDeviceOffers() {
 	// arrange db, insert some user_devices
 	ddID := ksuid.New().String()
@@ -324,6 +362,11 @@ func (s *UserDeviceServiceTestSuite) TestGetUserDeviceOffers() {
 		carmaxOffer.DeclineReason)
 }
 
+// testProviders registry with the real providers, only usable for projecting valuations since they have no dependencies set
+func testProviders() *ValuationProviderRegistry {
+	return NewValuationProviderRegistry(&drivlyValuationService{}, &vincarioValuationService{})
+}
+
 // setupCreateValuationsData creates valuation requests with some standards. request mileage: 49957, zip: 48216. if pdb nil just returns
 func setupCreateValuationsData(t *testing.T, tokenID uint64, ddID, vin string, md map[string][]byte, pdb *db.Store) *models.Valuation {
 	val := models.Valuation{
@@ -352,3 +395,52 @@ func setupCreateValuationsData(t *testing.T, tokenID uint64, ddID, vin string, m
 
 	return &val
 }
+
+func Test_getUserDeviceOffers_expiry(t *testing.T) {
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	expired := setupCreateValuationsData(t, 123, ksuid.New().String(), "vinny", map[string][]byte{
+		"OfferMetadata": []byte(testDrivlyOffersJSON),
+	}, nil)
+	expired.UpdatedAt = now.AddDate(0, 0, -8)
+	current := setupCreateValuationsData(t, 123, ksuid.New().String(), "vinny", map[string][]byte{
+		"OfferMetadata": []byte(testDrivlyOffersJSON),
+	}, nil)
+	current.UpdatedAt = now.AddDate(0, 0, -1)
+
+	offers, err := getUserDeviceOffers(models.ValuationSlice{expired, current}, now)
+
+	require.NoError(t, err)
+	require.Len(t, offers.OfferSets, 2)
+	assert.False(t, offers.OfferSets[0].Expired)
+	assert.Equal(t, "2024-06-16T00:00:00Z", offers.OfferSets[0].ExpiresAt)
+	assert.True(t, offers.OfferSets[1].Expired)
+	assert.Equal(t, "2024-06-09T00:00:00Z", offers.OfferSets[1].ExpiresAt)
+	// offer links are kept for expired offers, clients check expired
+	assert.Equal(t, offers.OfferSets[0].Offers, offers.OfferSets[1].Offers)
+}
+
+func Test_getUserDeviceOffers_mileageSource(t *testing.T) {
+	now := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	offer := func(requestJSON string) core.OfferSet {
+		row := setupCreateValuationsData(t, 123, ksuid.New().String(), "vinny", map[string][]byte{
+			"OfferMetadata":   []byte(testDrivlyOffersJSON),
+			"RequestMetadata": []byte(requestJSON),
+		}, nil)
+		offers, err := getUserDeviceOffers(models.ValuationSlice{row}, now)
+		require.NoError(t, err)
+		require.Len(t, offers.OfferSets, 1)
+		return offers.OfferSets[0]
+	}
+
+	reported := offer(`{"mileage": 48000, "mileageSource": "telemetry", "zipCode": "48216"}`)
+	assert.Equal(t, core.Real, reported.OdometerMeasurementType, "a real odometer that is a multiple of 12000")
+	assert.Equal(t, 48000, reported.Mileage)
+	assert.Equal(t, "48216", reported.ZipCode)
+
+	estimated := offer(`{"mileage": 49957, "mileageSource": "extrapolated"}`)
+	assert.Equal(t, core.Estimated, estimated.OdometerMeasurementType)
+
+	unknown := offer(`null`)
+	assert.Equal(t, core.Estimated, unknown.OdometerMeasurementType, "offers from before the request was recorded")
+	assert.Zero(t, unknown.Mileage)
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"time"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/core/units"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/volatiletech/null/v8"
+)
+
+// valuationColumnsVersion version of the projection into the valuation columns. Bump it with each migration adding a
+// projected column, or when the projection changes, so backfill-valuation-columns re-projects the existing rows. Rows
+// behind it are read from the vendor json until then.
+//
+//	1: 20251021090000 prices, mileage, odometer and zip code, rows written with it have a null columns_version
+//	2: 20251022090000 clean and rough tiers, value sources
+//	3: 20251023090000 blending strategy
+//	4: 20251024090000 averages, condition tier
+//	5: 20251025090000 battery adjustment
+//	6: 20251026090000 mileage source
+//	7: drivly odometer unit
+//	8: backfilled rows blended with the strategy of the country they were requested in
+const valuationColumnsVersion = 8
+
+// valuationColumns the projected columns on the valuations table, written at pull time or by the backfill
+var valuationColumns = []string{
+	models.ValuationColumns.Vendor,
+	models.ValuationColumns.TradeIn,
+	models.ValuationColumns.Retail,
+	models.ValuationColumns.UserDisplayPrice,
+	models.ValuationColumns.Currency,
+	models.ValuationColumns.Mileage,
+	models.ValuationColumns.OdometerType,
+	models.ValuationColumns.OdometerUnit,
+	models.ValuationColumns.ZipCode,
+	models.ValuationColumns.TradeInClean,
+	models.ValuationColumns.TradeInRough,
+	models.ValuationColumns.RetailClean,
+	models.ValuationColumns.RetailRough,
+	models.ValuationColumns.ValueSources,
+	models.ValuationColumns.BlendingStrategy,
+	models.ValuationColumns.TradeInAverage,
+	models.ValuationColumns.RetailAverage,
+	models.ValuationColumns.ConditionTier,
+	models.ValuationColumns.BatteryAdjustment,
+	models.ValuationColumns.BatteryStateOfHealth,
+	models.ValuationColumns.MileageSource,
+	models.ValuationColumns.ColumnsVersion,
+}
+
+// valueSources the book values behind the blended prices, stored in the value_sources column
+type valueSources struct {
+	TradeIn []core.ValuationSource `json:"tradeIn,omitempty"`
+	Retail  []core.ValuationSource `json:"retail,omitempty"`
+}
+
+// setValuationColumns stores the projected valuation set in the row's columns, so reads don't have to parse the vendor json
+func setValuationColumns(valuation *models.Valuation, valSet *core.ValuationSet) {
+	valuation.ColumnsVersion = null.IntFrom(valuationColumnsVersion)
+	valuation.Vendor = null.StringFrom(valSet.Vendor)
+	valuation.TradeIn = null.IntFrom(valSet.TradeIn)
+	valuation.Retail = null.IntFrom(valSet.Retail)
+	valuation.UserDisplayPrice = null.IntFrom(valSet.UserDisplayPrice)
+	valuation.BlendingStrategy = null.NewString(valSet.BlendingStrategy, valSet.BlendingStrategy != "")
+	valuation.Currency = null.NewString(valSet.Currency, valSet.Currency != "")
+	valuation.Mileage = null.IntFrom(valSet.Mileage)
+	valuation.MileageSource = null.NewString(valSet.MileageSource.String(), valSet.MileageSource != "")
+	valuation.OdometerType = null.NewString(valSet.OdometerMeasurementType.String(), valSet.OdometerMeasurementType != "")
+	// no unit when vincario didn't report the market odometer
+	valuation.OdometerUnit = null.NewString(valSet.OdometerUnit, valSet.OdometerUnit != "")
+	valuation.ZipCode = null.NewString(valSet.ZipCode, valSet.ZipCode != "")
+	valuation.TradeInClean = null.NewInt(valSet.TradeInClean, valSet.TradeInClean != 0)
+	valuation.TradeInRough = null.NewInt(valSet.TradeInRough, valSet.TradeInRough != 0)
+	valuation.TradeInAverage = null.IntFrom(valSet.TradeInAverage)
+	valuation.RetailClean = null.NewInt(valSet.RetailClean, valSet.RetailClean != 0)
+	valuation.RetailRough = null.NewInt(valSet.RetailRough, valSet.RetailRough != 0)
+	valuation.RetailAverage = null.IntFrom(valSet.RetailAverage)
+	valuation.ConditionTier = null.NewString(valSet.ConditionTier.String(), valSet.ConditionTier != "")
+	valuation.BatteryAdjustment = null.NewInt(valSet.BatteryAdjustment, valSet.BatteryStateOfHealth != 0)
+	valuation.BatteryStateOfHealth = null.NewFloat64(valSet.BatteryStateOfHealth, valSet.BatteryStateOfHealth != 0)
+	valuation.ValueSources = null.JSON{}
+	if len(valSet.TradeInSources) > 0 || len(valSet.RetailSources) > 0 {
+		_ = valuation.ValueSources.Marshal(valueSources{TradeIn: valSet.TradeInSources, Retail: valSet.RetailSources})
+	}
+}
+
+// valuationSetFromColumns builds the valuation set from the row's projected columns, nil if they have not been set or
+// were written by an older projection
+func valuationSetFromColumns(valuation *models.Valuation) *core.ValuationSet {
+	if !valuation.Vendor.Valid || !valuationColumnsCurrent(valuation) {
+		return nil
+	}
+	valSet := &core.ValuationSet{
+		Updated:                 valuation.UpdatedAt.Format(time.RFC3339),
+		Vendor:                  valuation.Vendor.String,
+		TradeInSource:           valuation.Vendor.String,
+		RetailSource:            valuation.Vendor.String,
+		TradeIn:                 valuation.TradeIn.Int,
+		TradeInClean:            valuation.TradeInClean.Int,
+		TradeInAverage:          valuation.TradeInAverage.Int,
+		TradeInRough:            valuation.TradeInRough.Int,
+		Retail:                  valuation.Retail.Int,
+		RetailClean:             valuation.RetailClean.Int,
+		RetailAverage:           valuation.RetailAverage.Int,
+		RetailRough:             valuation.RetailRough.Int,
+		UserDisplayPrice:        valuation.UserDisplayPrice.Int,
+		BlendingStrategy:        valuation.BlendingStrategy.String,
+		ConditionTier:           core.ConditionTier(valuation.ConditionTier.String),
+		BatteryAdjustment:       valuation.BatteryAdjustment.Int,
+		BatteryStateOfHealth:    valuation.BatteryStateOfHealth.Float64,
+		Currency:                valuation.Currency.String,
+		Mileage:                 valuation.Mileage.Int,
+		MileageSource:           core.MileageSource(valuation.MileageSource.String),
+		OdometerMeasurementType: core.OdometerMeasurementEnum(valuation.OdometerType.String),
+		ZipCode:                 valuation.ZipCode.String,
+	}
+	// rows written before the averages had their own columns, where the price always was the average
+	if !valuation.TradeInAverage.Valid {
+		valSet.TradeInAverage = valSet.TradeIn
+	}
+	if !valuation.RetailAverage.Valid {
+		valSet.RetailAverage = valSet.Retail
+	}
+	if valuation.ValueSources.Valid {
+		var sources valueSources
+		if err := valuation.ValueSources.Unmarshal(&sources); err == nil {
+			valSet.TradeInSources = sources.TradeIn
+			valSet.RetailSources = sources.Retail
+		}
+	}
+	if valSet.Vendor == DrivlyProvider {
+		valSet.TradeInSource = drivlySourceName(valSet.TradeInSources)
+		valSet.RetailSource = drivlySourceName(valSet.RetailSources)
+	}
+	// the odometer is the mileage for both vendors
+	valSet.Odometer = valuation.Mileage.Int
+	valSet.OdometerUnit = normalizeOdometerUnit(valuation.OdometerUnit.String)
+	if !valuation.OdometerUnit.Valid {
+		valSet.OdometerUnit = defaultOdometerUnit(valuation)
+	}
+	return valSet
+}
+
+// defaultOdometerUnit the unit of rows written without one: drivly is always requested in miles, vincario reports it
+// with the market odometer
+func defaultOdometerUnit(valuation *models.Valuation) string {
+	switch valuation.Vendor.String {
+	case DrivlyProvider:
+		return units.Miles.String()
+	case VincarioProvider:
+		if unit := vincarioOdometerRegion(valuation.VincarioMetadata.JSON).Get("odometer_unit"); unit.Exists() {
+			return normalizeOdometerUnit(unit.String())
+		}
+	}
+	return ""
+}
+
+// valuationColumnsCurrent whether the row was projected with the current valuationColumnsVersion
+func valuationColumnsCurrent(valuation *models.Valuation) bool {
+	return valuation.ColumnsVersion.Valid && valuation.ColumnsVersion.Int >= valuationColumnsVersion
+}
+
+// normalizeOdometerUnit km or miles for the units vendors report, and rows written before units were normalized. Unknown
+// units are kept as is
+func normalizeOdometerUnit(unit string) string {
+	if u, err := units.ParseDistanceUnit(unit); err == nil {
+		return u.String()
+	}
+	return unit
+}
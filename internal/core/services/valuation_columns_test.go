@@ -0,0 +1,103 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_valuationColumns_roundTrip(t *testing.T) {
+	logger := zerolog.Nop()
+	registry := testProviders()
+	for name, row := range map[string]*models.Valuation{
+		"drivly": {
+			Vin:                   "vin1",
+			DrivlyPricingMetadata: null.JSONFrom([]byte(testDrivlyPricingJSON)),
+			RequestMetadata:       null.JSONFrom([]byte(`{"mileage": 49957, "zipCode": "48216"}`)),
+			UpdatedAt:             time.Now(),
+		},
+		"vincario": {
+			Vin:              "vin2",
+			VincarioMetadata: null.JSONFrom([]byte(testVincarioValuationJSON)),
+			RequestMetadata:  null.JSONFrom([]byte(`{"zipCode": "10115"}`)),
+			UpdatedAt:        time.Now(),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fromJSON := registry.ProjectValuation(&logger, row, "")
+			require.NotNil(t, fromJSON)
+			assert.Nil(t, valuationSetFromColumns(row), "columns not set yet")
+
+			setValuationColumns(row, fromJSON)
+			// no vendor json, so this can only come from the columns
+			columnsOnly := *row
+			columnsOnly.DrivlyPricingMetadata = null.JSON{}
+			columnsOnly.VincarioMetadata = null.JSON{}
+			columnsOnly.RequestMetadata = null.JSON{}
+			fromColumns := registry.ProjectValuation(&logger, &columnsOnly, "")
+			assert.Equal(t, fromJSON, fromColumns)
+		})
+	}
+}
+
+func Test_valuationColumns_noMarketValue(t *testing.T) {
+	logger := zerolog.Nop()
+	row := &models.Valuation{Vendor: null.StringFrom(DrivlyProvider), TradeIn: null.IntFrom(0), Retail: null.IntFrom(0)}
+
+	assert.Nil(t, testProviders().ProjectValuation(&logger, row, ""))
+}
+
+func Test_valuationColumns_olderVersion(t *testing.T) {
+	logger := zerolog.Nop()
+	registry := testProviders()
+	row := &models.Valuation{
+		Vin:                   "vin1",
+		DrivlyPricingMetadata: null.JSONFrom([]byte(testDrivlyPricingJSON)),
+		RequestMetadata:       null.JSONFrom([]byte(`{"mileage": 49957, "zipCode": "48216"}`)),
+		UpdatedAt:             time.Now(),
+	}
+	fromJSON := registry.ProjectValuation(&logger, row, "")
+	require.NotNil(t, fromJSON)
+	setValuationColumns(row, fromJSON)
+	assert.Equal(t, null.IntFrom(valuationColumnsVersion), row.ColumnsVersion)
+
+	// as written before the tiers had columns
+	row.ColumnsVersion = null.Int{}
+	row.TradeInClean = null.Int{}
+	row.RetailClean = null.Int{}
+
+	assert.Nil(t, valuationSetFromColumns(row), "older projections are not read")
+	assert.Equal(t, fromJSON, registry.ProjectValuation(&logger, row, ""), "falls back to the vendor json")
+}
+
+func Test_valuationSetFromColumns_noOdometerUnit(t *testing.T) {
+	for name, tc := range map[string]struct {
+		row  *models.Valuation
+		unit string
+	}{
+		"drivly": {
+			row:  &models.Valuation{Vendor: null.StringFrom(DrivlyProvider)},
+			unit: "miles",
+		},
+		"vincario": {
+			row:  &models.Valuation{Vendor: null.StringFrom(VincarioProvider), VincarioMetadata: null.JSONFrom([]byte(testVincarioValuationJSON))},
+			unit: "km",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.row.ColumnsVersion = null.IntFrom(valuationColumnsVersion)
+			tc.row.Mileage = null.IntFrom(49957)
+
+			valSet := valuationSetFromColumns(tc.row)
+
+			require.NotNil(t, valSet)
+			assert.Equal(t, 49957, valSet.Odometer)
+			assert.Equal(t, tc.unit, valSet.OdometerUnit)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/core/gateways"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"golang.org/x/sync/errgroup"
+)
+
+//go:generate mockgen -source valuation_job_service.go -destination mocks/valuation_job_service_mock.go
+
+// ValuationJobService queues valuation and offer requests on the NATS stream so the vendor calls happen outside the http
+// request, and tracks their status.
+type ValuationJobService interface {
+	// Enqueue publishes a valuation or offer job for the vehicle and returns it with Queued status. The latest signals the
+	// caller already has are queued as is, the rest of the vehicle telemetry is resolved with the privilege token and
+	// queued with the job, the token itself is not. A job that fails to publish is recorded with Error status
+	Enqueue(ctx context.Context, jobType core.JobType, tokenID uint64, vin, countryCode, privJWTAuthHeader string,
+		signals *core.SignalsLatest) (*core.ValuationJob, error)
+	// GetJob returns the job status, ErrJobNotFound if it does not exist or has expired
+	GetJob(ctx context.Context, jobID string) (*core.ValuationJob, error)
+	// RunWorker consumes and processes jobs until the context is cancelled
+	RunWorker(ctx context.Context) error
+}
+
+// ErrJobNotFound the job id does not exist or has expired
+var ErrJobNotFound = errors.New("job not found")
+
+// how long a worker waits for a job before checking if it should stop
+const jobFetchWait = 5 * time.Second
+
+type valuationJobService struct {
+	natsSvc      *NATSService
+	jobs         nats.KeyValue
+	providers    *ValuationProviderRegistry
+	telemetryAPI gateways.TelemetryAPI
+	log          *zerolog.Logger
+}
+
+func NewValuationJobService(natsSvc *NATSService, providers *ValuationProviderRegistry, telemetryAPI gateways.TelemetryAPI,
+	log *zerolog.Logger) ValuationJobService {
+	return &valuationJobService{
+		natsSvc:      natsSvc,
+		jobs:         natsSvc.JobsBucket,
+		providers:    providers,
+		telemetryAPI: telemetryAPI,
+		log:          log,
+	}
+}
+
+func (s *valuationJobService) Enqueue(ctx context.Context, jobType core.JobType, tokenID uint64, vin, countryCode, privJWTAuthHeader string,
+	signals *core.SignalsLatest) (*core.ValuationJob, error) {
+	now := time.Now().UTC()
+	job := &core.ValuationJob{
+		ID:        ksuid.New().String(),
+		Type:      jobType,
+		TokenID:   tokenID,
+		Status:    core.QueuedDataPullStatus,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.putJob(job); err != nil {
+		return nil, err
+	}
+
+	msg, err := json.Marshal(core.ValuationJobRequest{
+		JobID:       job.ID,
+		TokenID:     tokenID,
+		Vin:         vin,
+		CountryCode: countryCode,
+		Telemetry:   s.vehicleTelemetry(ctx, tokenID, privJWTAuthHeader, signals),
+	})
+	if err != nil {
+		return nil, err
+	}
+	subject := s.natsSvc.ValuationSubject
+	if jobType == core.OfferJobType {
+		subject = s.natsSvc.OfferSubject
+	}
+	if _, err := s.natsSvc.JetStream.Publish(subject, msg, nats.Context(ctx)); err != nil {
+		err = errors.Wrapf(err, "failed to publish %s job", jobType)
+		// no worker will pick it up, don't leave it queued for whoever polls it
+		job.Status = core.ErrorDataPullStatus
+		job.Error = err.Error()
+		localLog := s.log.With().Str("job_id", job.ID).Uint64("token_id", tokenID).Logger()
+		s.updateJob(&localLog, job)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// vehicleTelemetry the signals the providers pull with, the latest signals are the ones the request was routed with.
+// Signals that can't be had are left out, the providers carry on without them
+func (s *valuationJobService) vehicleTelemetry(ctx context.Context, tokenID uint64, privJWTAuthHeader string,
+	signals *core.SignalsLatest) core.VehicleTelemetry {
+	telemetry := core.VehicleTelemetry{Signals: signals}
+	if privJWTAuthHeader == "" {
+		return telemetry
+	}
+	localLog := s.log.With().Uint64("token_id", tokenID).Logger()
+	var err error
+	if telemetry.ConditionSignals, err = s.telemetryAPI.GetConditionSignals(ctx, tokenID, privJWTAuthHeader); err != nil {
+		localLog.Warn().Err(err).Msg("could not get telemetry condition signals, continuing without")
+	}
+	telemetry.OdometerHistory = odometerHistory(ctx, s.telemetryAPI, &localLog, tokenID, privJWTAuthHeader, telemetry.Signals, time.Now())
+	return telemetry
+}
+
+func (s *valuationJobService) GetJob(_ context.Context, jobID string) (*core.ValuationJob, error) {
+	entry, err := s.jobs.Get(jobID)
+	if err != nil {
+		if errors.Is(err, nats.ErrKeyNotFound) || errors.Is(err, nats.ErrInvalidKey) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	job := &core.ValuationJob{}
+	if err := json.Unmarshal(entry.Value(), job); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal job "+jobID)
+	}
+	return job, nil
+}
+
+// RunWorker consumes both queues, if one consumer fails the other is stopped and the error returned
+func (s *valuationJobService) RunWorker(ctx context.Context) error {
+	g, gCtx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return s.consume(gCtx, core.ValuationJobType, s.natsSvc.ValuationSubject, s.natsSvc.ValuationDurableConsumer)
+	})
+	g.Go(func() error {
+		return s.consume(gCtx, core.OfferJobType, s.natsSvc.OfferSubject, s.natsSvc.OfferDurableConsumer)
+	})
+	return g.Wait()
+}
+
+func (s *valuationJobService) consume(ctx context.Context, jobType core.JobType, subject, durable string) error {
+	// the consumer is created here and bound to, a consumer created by the subscription would be deleted when it is
+	// unsubscribed, from under the other workers
+	_, err := s.natsSvc.JetStream.AddConsumer(s.natsSvc.JetStreamName, &nats.ConsumerConfig{
+		Durable:       durable,
+		FilterSubject: subject,
+		AckPolicy:     nats.AckExplicitPolicy,
+		AckWait:       s.natsSvc.AckTimeout,
+	}, nats.Context(ctx))
+	// one created before with other settings is used as is
+	if err != nil && !errors.Is(err, nats.ErrConsumerNameAlreadyInUse) {
+		return errors.Wrapf(err, "failed to create %s consumer", durable)
+	}
+	sub, err := s.natsSvc.JetStream.PullSubscribe(subject, durable, nats.Bind(s.natsSvc.JetStreamName, durable), nats.ManualAck())
+	if err != nil {
+		return errors.Wrapf(err, "failed to subscribe to %s", subject)
+	}
+	defer func() {
+		if err := sub.Unsubscribe(); err != nil {
+			s.log.Warn().Err(err).Msgf("failed to unsubscribe from %s", subject)
+		}
+	}()
+	s.log.Info().Msgf("consuming %s jobs from %s", jobType, subject)
+
+	for ctx.Err() == nil {
+		fetchCtx, cancel := context.WithTimeout(ctx, jobFetchWait)
+		msgs, err := sub.Fetch(1, nats.Context(fetchCtx))
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, nats.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+				continue
+			}
+			s.log.Err(err).Msgf("failed to fetch %s jobs", jobType)
+			select {
+			case <-ctx.Done():
+			case <-time.After(jobFetchWait):
+			}
+			continue
+		}
+		for _, msg := range msgs {
+			s.handle(ctx, jobType, msg)
+		}
+	}
+	return nil
+}
+
+// handle processes the job and acks the message. The job outcome is recorded in the job status so failed pulls are not
+// redelivered, only messages that are never acked (eg. the worker died) or interrupted by the worker stopping are.
+func (s *valuationJobService) handle(ctx context.Context, jobType core.JobType, msg *nats.Msg) {
+	req := core.ValuationJobRequest{}
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		s.log.Err(err).Msgf("invalid %s job message, dropping", jobType)
+		_ = msg.Term()
+		return
+	}
+
+	// vendor calls can take longer than the ack timeout, keep the message from being redelivered while we wait
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(s.natsSvc.AckTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				_ = msg.InProgress()
+			}
+		}
+	}()
+	job := s.process(ctx, jobType, &req)
+	close(done)
+
+	if ctx.Err() != nil {
+		// the worker is stopping, leave the job for another worker
+		if err := msg.Nak(); err != nil {
+			s.log.Err(err).Str("job_id", job.ID).Msg("failed to nak interrupted job")
+		}
+		return
+	}
+	if err := msg.Ack(); err != nil {
+		s.log.Err(err).Str("job_id", job.ID).Msg("failed to ack job")
+	}
+}
+
+// process pulls from the providers routed for the job's country and records the outcome in the job status. A job
+// interrupted by the context being cancelled goes back to queued
+func (s *valuationJobService) process(ctx context.Context, jobType core.JobType, req *core.ValuationJobRequest) *core.ValuationJob {
+	localLog := s.log.With().Str("job_id", req.JobID).Uint64("token_id", req.TokenID).Str("job_type", string(jobType)).Logger()
+
+	job, err := s.GetJob(ctx, req.JobID)
+	if err != nil {
+		localLog.Warn().Err(err).Msg("could not get job status, recreating it")
+		job = &core.ValuationJob{ID: req.JobID, Type: jobType, TokenID: req.TokenID, CreatedAt: time.Now().UTC()}
+	}
+	job.Status = core.ProcessingDataPullStatus
+	s.updateJob(&localLog, job)
+
+	var status core.DataPullStatusEnum
+	if jobType == core.OfferJobType {
+		status, err = s.providers.PullOffer(ctx, req.CountryCode, req.TokenID, req.Vin, req.Telemetry)
+	} else {
+		status, err = s.providers.PullValuation(ctx, req.CountryCode, req.TokenID, req.Vin, req.Telemetry)
+	}
+	job.Status = status
+	switch {
+	case ctx.Err() != nil:
+		localLog.Warn().Err(err).Msg("job interrupted, requeueing")
+		job.Status = core.QueuedDataPullStatus
+		job.Error = ""
+	case err != nil:
+		localLog.Err(err).Msg("job failed")
+		job.Status = core.ErrorDataPullStatus
+		job.Error = err.Error()
+	default:
+		localLog.Info().Msgf("job completed with status %s", status)
+	}
+	s.updateJob(&localLog, job)
+
+	return job
+}
+
+func (s *valuationJobService) updateJob(localLog *zerolog.Logger, job *core.ValuationJob) {
+	job.UpdatedAt = time.Now().UTC()
+	if err := s.putJob(job); err != nil {
+		localLog.Err(err).Msgf("failed to update job status to %s", job.Status)
+	}
+}
+
+func (s *valuationJobService) putJob(job *core.ValuationJob) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if _, err := s.jobs.Put(job.ID, b); err != nil {
+		return errors.Wrap(err, "failed to store job "+job.ID)
+	}
+	return nil
+}
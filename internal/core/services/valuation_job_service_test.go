@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mock_gateways "github.com/DIMO-Network/valuations-api/internal/core/gateways/mocks"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	mock_services "github.com/DIMO-Network/valuations-api/internal/core/services/mocks"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/dbtest"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// fakeKeyValue in memory jobs bucket, only Get and Put are implemented
+type fakeKeyValue struct {
+	nats.KeyValue
+	values map[string][]byte
+}
+
+type fakeKeyValueEntry struct {
+	nats.KeyValueEntry
+	value []byte
+}
+
+func (e *fakeKeyValueEntry) Value() []byte { return e.value }
+
+func (kv *fakeKeyValue) Get(key string) (nats.KeyValueEntry, error) {
+	v, ok := kv.values[key]
+	if !ok {
+		return nil, nats.ErrKeyNotFound
+	}
+	return &fakeKeyValueEntry{value: v}, nil
+}
+
+func (kv *fakeKeyValue) Put(key string, value []byte) (uint64, error) {
+	kv.values[key] = value
+	return uint64(len(kv.values)), nil
+}
+
+// failingJetStream fails every publish
+type failingJetStream struct {
+	nats.JetStreamContext
+}
+
+func (failingJetStream) Publish(string, []byte, ...nats.PubOpt) (*nats.PubAck, error) {
+	return nil, errors.New("nats: no responders available for request")
+}
+
+func newTestJobService(t *testing.T) (*valuationJobService, *mock_services.MockValuationProvider) {
+	svc, provider, _ := newTestJobServiceWithTelemetry(t)
+	return svc, provider
+}
+
+func newTestJobServiceWithTelemetry(t *testing.T) (*valuationJobService, *mock_services.MockValuationProvider, *mock_gateways.MockTelemetryAPI) {
+	mockCtrl := gomock.NewController(t)
+	telemetryAPI := mock_gateways.NewMockTelemetryAPI(mockCtrl)
+	provider := mock_services.NewMockValuationProvider(mockCtrl)
+	provider.EXPECT().Name().Return(DrivlyProvider).AnyTimes()
+	provider.EXPECT().SupportedCountries().Return([]string{"USA"}).AnyTimes()
+
+	return &valuationJobService{
+		natsSvc:      &NATSService{},
+		jobs:         &fakeKeyValue{values: map[string][]byte{}},
+		providers:    NewValuationProviderRegistry(provider),
+		telemetryAPI: telemetryAPI,
+		log:          dbtest.Logger(),
+	}, provider, telemetryAPI
+}
+
+func Test_valuationJobService_process(t *testing.T) {
+	svc, provider := newTestJobService(t)
+	ctx := context.Background()
+	job := &core.ValuationJob{ID: "job1", Type: core.ValuationJobType, TokenID: 123, Status: core.QueuedDataPullStatus}
+	require.NoError(t, svc.putJob(job))
+	telemetry := core.VehicleTelemetry{Signals: &core.SignalsLatest{}}
+	provider.EXPECT().PullValuation(gomock.Any(), uint64(123), "vinny", telemetry).Return(core.PulledValuationDrivlyStatus, nil)
+
+	svc.process(ctx, core.ValuationJobType, &core.ValuationJobRequest{JobID: "job1", TokenID: 123, Vin: "vinny", CountryCode: "US", Telemetry: telemetry})
+
+	stored, err := svc.GetJob(ctx, "job1")
+	require.NoError(t, err)
+	assert.Equal(t, core.PulledValuationDrivlyStatus, stored.Status)
+	assert.Empty(t, stored.Error)
+}
+
+func Test_valuationJobService_process_error(t *testing.T) {
+	svc, provider := newTestJobService(t)
+	ctx := context.Background()
+	provider.EXPECT().PullValuation(gomock.Any(), uint64(123), "vinny", core.VehicleTelemetry{}).Return(core.ErrorDataPullStatus, errors.New("drivly down"))
+
+	// job status missing, eg. expired, gets recreated
+	svc.process(ctx, core.ValuationJobType, &core.ValuationJobRequest{JobID: "job2", TokenID: 123, Vin: "vinny", CountryCode: "USA"})
+
+	stored, err := svc.GetJob(ctx, "job2")
+	require.NoError(t, err)
+	assert.Equal(t, core.ErrorDataPullStatus, stored.Status)
+	assert.Contains(t, stored.Error, "drivly down")
+	assert.Equal(t, uint64(123), stored.TokenID)
+}
+
+func Test_valuationJobService_process_interrupted(t *testing.T) {
+	svc, provider := newTestJobService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	provider.EXPECT().PullValuation(gomock.Any(), uint64(123), "vinny", core.VehicleTelemetry{}).DoAndReturn(
+		func(context.Context, uint64, string, core.VehicleTelemetry) (core.DataPullStatusEnum, error) {
+			// worker stopping mid pull
+			cancel()
+			return core.ErrorDataPullStatus, context.Canceled
+		})
+
+	svc.process(ctx, core.ValuationJobType, &core.ValuationJobRequest{JobID: "job3", TokenID: 123, Vin: "vinny", CountryCode: "USA"})
+
+	stored, err := svc.GetJob(context.Background(), "job3")
+	require.NoError(t, err)
+	assert.Equal(t, core.QueuedDataPullStatus, stored.Status)
+	assert.Empty(t, stored.Error)
+}
+
+func Test_valuationJobService_vehicleTelemetry(t *testing.T) {
+	svc, _, telemetryAPI := newTestJobServiceWithTelemetry(t)
+	ctx := context.Background()
+	signals := &core.SignalsLatest{PowertrainTransmissionTravelledDistance: core.TimeFloatValue{Timestamp: time.Now(), Value: 1000}}
+	telemetryAPI.EXPECT().GetConditionSignals(gomock.Any(), uint64(123), "Bearer xx").Return(nil, errors.New("forbidden"))
+
+	telemetry := svc.vehicleTelemetry(ctx, 123, "Bearer xx", signals)
+
+	assert.Equal(t, signals, telemetry.Signals)
+	assert.Nil(t, telemetry.ConditionSignals)
+	assert.Nil(t, telemetry.OdometerHistory, "odometer is fresh")
+	assert.Equal(t, core.VehicleTelemetry{}, svc.vehicleTelemetry(ctx, 123, "", nil), "nothing without a token")
+}
+
+func Test_valuationJobService_Enqueue_publishFailed(t *testing.T) {
+	svc, _ := newTestJobService(t)
+	svc.natsSvc.JetStream = failingJetStream{}
+	kv := svc.jobs.(*fakeKeyValue)
+
+	_, err := svc.Enqueue(context.Background(), core.ValuationJobType, 123, "vin", "USA", "", nil)
+
+	require.Error(t, err)
+	require.Len(t, kv.values, 1)
+	for id := range kv.values {
+		job, err := svc.GetJob(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, core.ErrorDataPullStatus, job.Status, "not left queued")
+		assert.Contains(t, job.Error, "failed to publish valuation job")
+	}
+}
+
+func Test_valuationJobService_GetJob_notFound(t *testing.T) {
+	svc, _ := newTestJobService(t)
+
+	_, err := svc.GetJob(context.Background(), "nope")
+
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/DIMO-Network/valuations-api/internal/infrastructure/db/models"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/tidwall/gjson"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// names of the vendors we pull valuations or offers from. Also used as the Vendor in core.ValuationSet
+const (
+	DrivlyProvider   = "drivly"
+	VincarioProvider = "vincario"
+)
+
+//go:generate mockgen -source valuation_provider.go -destination mocks/valuation_provider_mock.go
+
+// ValuationProvider is a vendor we can pull market valuations from. Each provider stores its raw response in its own
+// valuations column and knows how to project it into a core.ValuationSet.
+type ValuationProvider interface {
+	// Name of the vendor, eg. drivly
+	Name() string
+	// PullValuation requests a new valuation from the vendor and stores it, skipping if one was pulled within the RepullWindow
+	PullValuation(ctx context.Context, tokenID uint64, vin string, telemetry core.VehicleTelemetry) (core.DataPullStatusEnum, error)
+	// ProjectValuation builds the valuation set from the vendor data in the row, returns nil if the row has no data from this vendor
+	ProjectValuation(valuation *models.Valuation, countryCode string) *core.ValuationSet
+	// MetadataColumn is the valuations table column holding the raw vendor response
+	MetadataColumn() string
+	// SupportedCountries ISO 3166-1 alpha-3 country codes the vendor can value, used when the routing table has no route for a country
+	SupportedCountries() []string
+	// RepullWindow how long a pulled valuation is considered fresh
+	RepullWindow() time.Duration
+}
+
+// OfferProvider is a vendor we can request instant offers from
+type OfferProvider interface {
+	// Name of the vendor, eg. drivly
+	Name() string
+	// PullOffer requests instant offers from the vendor and stores them
+	PullOffer(ctx context.Context, tokenID uint64, vin string, telemetry core.VehicleTelemetry) (core.DataPullStatusEnum, error)
+	// OfferCountries ISO 3166-1 alpha-3 country codes the vendor can make offers in, used when the routing table has no route for a country
+	OfferCountries() []string
+}
+
+// ErrUnsupportedCountry no provider is routed for the vehicle's country
+var ErrUnsupportedCountry = errors.New("unsupported country")
+
+// ValuationProviderRegistry holds the configured valuation and offer providers, in order of preference, and the
+// routing of countries to providers.
+type ValuationProviderRegistry struct {
+	valuationProviders []ValuationProvider
+	offerProviders     []OfferProvider
+	valuationRouting   ProviderRouting
+	offerRouting       ProviderRouting
+}
+
+// NewValuationProviderRegistry registers the providers in order of preference. Providers that also implement OfferProvider
+// are registered as offer providers.
+func NewValuationProviderRegistry(providers ...ValuationProvider) *ValuationProviderRegistry {
+	r := &ValuationProviderRegistry{}
+	for _, p := range providers {
+		r.valuationProviders = append(r.valuationProviders, p)
+		if op, ok := p.(OfferProvider); ok {
+			r.offerProviders = append(r.offerProviders, op)
+		}
+	}
+	return r
+}
+
+// ValuationProviders returns all registered valuation providers
+func (r *ValuationProviderRegistry) ValuationProviders() []ValuationProvider {
+	return r.valuationProviders
+}
+
+// Valuation gets the valuation provider by name, nil if not registered
+func (r *ValuationProviderRegistry) Valuation(name string) ValuationProvider {
+	for _, p := range r.valuationProviders {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Offer gets the offer provider by name, nil if not registered
+func (r *ValuationProviderRegistry) Offer(name string) OfferProvider {
+	for _, p := range r.offerProviders {
+		if p.Name() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// SetRouting sets the country routing tables for valuations and offers. Countries without a route fall back to the
+// providers' own supported countries, in order of registration. Errors if a route names a provider that is not registered.
+func (r *ValuationProviderRegistry) SetRouting(valuations, offers ProviderRouting) error {
+	for _, names := range valuations {
+		for _, name := range names {
+			if r.Valuation(name) == nil {
+				return errors.Errorf("valuation routing references unknown provider %s", name)
+			}
+		}
+	}
+	for _, names := range offers {
+		for _, name := range names {
+			if r.Offer(name) == nil {
+				return errors.Errorf("offer routing references unknown provider %s", name)
+			}
+		}
+	}
+	r.valuationRouting = valuations
+	r.offerRouting = offers
+	return nil
+}
+
+// ValuationProvidersForCountry returns the valuation providers to try, in order, for the alpha-2 or alpha-3 country code
+func (r *ValuationProviderRegistry) ValuationProvidersForCountry(countryCode string) []ValuationProvider {
+	alpha3 := ConvertCountryToAlpha3(countryCode)
+	var providers []ValuationProvider
+	if names, ok := r.valuationRouting.Route(alpha3); ok {
+		for _, name := range names {
+			providers = append(providers, r.Valuation(name))
+		}
+		return providers
+	}
+	for _, p := range r.valuationProviders {
+		if containsCountry(p.SupportedCountries(), alpha3) {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+// OfferProvidersForCountry returns the offer providers to try, in order, for the alpha-2 or alpha-3 country code
+func (r *ValuationProviderRegistry) OfferProvidersForCountry(countryCode string) []OfferProvider {
+	alpha3 := ConvertCountryToAlpha3(countryCode)
+	var providers []OfferProvider
+	if names, ok := r.offerRouting.Route(alpha3); ok {
+		for _, name := range names {
+			providers = append(providers, r.Offer(name))
+		}
+		return providers
+	}
+	for _, p := range r.offerProviders {
+		if containsCountry(p.OfferCountries(), alpha3) {
+			providers = append(providers, p)
+		}
+	}
+	return providers
+}
+
+// PullValuation pulls a valuation from the providers routed for the country, in order, until one succeeds.
+// Returns ErrUnsupportedCountry if no provider is routed for the country, otherwise the last provider error.
+func (r *ValuationProviderRegistry) PullValuation(ctx context.Context, countryCode string, tokenID uint64, vin string, telemetry core.VehicleTelemetry) (core.DataPullStatusEnum, error) {
+	providers := r.ValuationProvidersForCountry(countryCode)
+	if len(providers) == 0 {
+		return core.SkippedDataPullStatus, errors.Wrapf(ErrUnsupportedCountry, "no valuation provider for country %s", countryCode)
+	}
+	var err error
+	for _, p := range providers {
+		var status core.DataPullStatusEnum
+		status, err = p.PullValuation(ctx, tokenID, vin, telemetry)
+		if err == nil {
+			return status, nil
+		}
+		err = errors.Wrapf(err, "%s valuation failed", p.Name())
+	}
+	return core.ErrorDataPullStatus, err
+}
+
+// PullOffer requests offers from the providers routed for the country, in order, until one succeeds.
+// Returns ErrUnsupportedCountry if no provider is routed for the country, otherwise the last provider error.
+func (r *ValuationProviderRegistry) PullOffer(ctx context.Context, countryCode string, tokenID uint64, vin string, telemetry core.VehicleTelemetry) (core.DataPullStatusEnum, error) {
+	providers := r.OfferProvidersForCountry(countryCode)
+	if len(providers) == 0 {
+		return core.SkippedDataPullStatus, errors.Wrapf(ErrUnsupportedCountry, "no offer provider for country %s", countryCode)
+	}
+	var err error
+	for _, p := range providers {
+		var status core.DataPullStatusEnum
+		status, err = p.PullOffer(ctx, tokenID, vin, telemetry)
+		if err == nil {
+			return status, nil
+		}
+		err = errors.Wrapf(err, "%s offer failed", p.Name())
+	}
+	return core.ErrorDataPullStatus, err
+}
+
+// HasValuationData query mod to filter valuations rows that contain data from any of the registered valuation providers
+func (r *ValuationProviderRegistry) HasValuationData() qm.QueryMod {
+	clauses := make([]string, len(r.valuationProviders))
+	for i, p := range r.valuationProviders {
+		clauses[i] = p.MetadataColumn() + " is not null"
+	}
+	return qm.Where("(" + strings.Join(clauses, " or ") + ")")
+}
+
+// ProjectValuation reads the valuation set from the row's projected columns, or for rows not backfilled yet projects the
+// vendor json with the first provider that has data in it. Returns nil if no provider had data or the data did not
+// contain a market value.
+func (r *ValuationProviderRegistry) ProjectValuation(logger *zerolog.Logger, valuation *models.Valuation, countryCode string) *core.ValuationSet {
+	if valSet := valuationSetFromColumns(valuation); valSet != nil {
+		if valSet.Retail > 0 || valSet.TradeIn > 0 {
+			return valSet
+		}
+		logger.Debug().Str("vin", valuation.Vin).Msgf("did not find a market value from %s", valSet.Vendor)
+		return nil
+	}
+	return r.projectVendorData(logger, valuation, countryCode)
+}
+
+// projectVendorData projects the vendor json in the row with the first provider that has data in it
+func (r *ValuationProviderRegistry) projectVendorData(logger *zerolog.Logger, valuation *models.Valuation, countryCode string) *core.ValuationSet {
+	for _, p := range r.valuationProviders {
+		valSet := p.ProjectValuation(valuation, countryCode)
+		if valSet == nil {
+			continue
+		}
+		// make sure valid data
+		if valSet.Retail > 0 || valSet.TradeIn > 0 {
+			return valSet
+		}
+		logger.Debug().Str("vin", valuation.Vin).Msgf("did not find a market value from %s, or valJSON in unexpected format", p.Name())
+		return nil
+	}
+	return nil
+}
+
+// requestCountryCode the country the valuation was requested in, empty for rows from before it was recorded. Projecting
+// with it blends the display price with the strategy of that country, as when the valuation was pulled
+func requestCountryCode(valuation *models.Valuation) string {
+	return gjson.GetBytes(valuation.RequestMetadata.JSON, "countryCode").String()
+}
+
+func containsCountry(countries []string, countryCode string) bool {
+	for _, c := range countries {
+		if strings.EqualFold(c, countryCode) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValuationProviderRegistry_ForCountry(t *testing.T) {
+	registry := testProviders()
+
+	require.Len(t, registry.ValuationProvidersForCountry("USA"), 1)
+	assert.Equal(t, DrivlyProvider, registry.ValuationProvidersForCountry("US")[0].Name())
+	require.Len(t, registry.ValuationProvidersForCountry("deu"), 1)
+	assert.Equal(t, VincarioProvider, registry.ValuationProvidersForCountry("DE")[0].Name())
+	assert.Empty(t, registry.ValuationProvidersForCountry("BRA"))
+
+	require.Len(t, registry.OfferProvidersForCountry("CA"), 1)
+	assert.Equal(t, DrivlyProvider, registry.OfferProvidersForCountry("CAN")[0].Name())
+	assert.Empty(t, registry.OfferProvidersForCountry("DEU"), "vincario does not make offers")
+}
+
+func Test_ValuationProviderRegistry_Routing(t *testing.T) {
+	registry := testProviders()
+	valuationRouting, err := ParseProviderRouting("USA:drivly;CA:drivly,vincario;*:vincario")
+	require.NoError(t, err)
+	offerRouting, err := ParseProviderRouting("US,CA:drivly")
+	require.NoError(t, err)
+	require.NoError(t, registry.SetRouting(valuationRouting, offerRouting))
+
+	canada := registry.ValuationProvidersForCountry("CA")
+	require.Len(t, canada, 2)
+	assert.Equal(t, DrivlyProvider, canada[0].Name())
+	assert.Equal(t, VincarioProvider, canada[1].Name())
+	require.Len(t, registry.ValuationProvidersForCountry("BR"), 1, "default route applies to any other country")
+	assert.Equal(t, VincarioProvider, registry.ValuationProvidersForCountry("BR")[0].Name())
+
+	assert.Len(t, registry.OfferProvidersForCountry("CAN"), 1)
+	assert.Len(t, registry.OfferProvidersForCountry("MEX"), 1, "mexico is not routed so falls back to supported countries")
+	assert.Empty(t, registry.OfferProvidersForCountry("DEU"))
+}
+
+func Test_ValuationProviderRegistry_SetRouting_unknownProvider(t *testing.T) {
+	registry := testProviders()
+	offerRouting, err := ParseProviderRouting("DEU:vincario")
+	require.NoError(t, err)
+
+	assert.Error(t, registry.SetRouting(nil, offerRouting), "vincario is not an offer provider")
+}
+
+func Test_ValuationProviderRegistry_ByName(t *testing.T) {
+	registry := testProviders()
+
+	assert.Equal(t, VincarioProvider, registry.Valuation(VincarioProvider).Name())
+	assert.Nil(t, registry.Valuation("blackbook"))
+	assert.Nil(t, registry.Offer(VincarioProvider))
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/valuations-api/internal/appmetrics"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrVendorRateLimited the vendor kept responding 429 after retrying
+	ErrVendorRateLimited = errors.New("vendor rate limited")
+	// ErrVINNotFound the vendor has no data for the VIN
+	ErrVINNotFound = errors.New("vin not found by vendor")
+	// ErrVendorDown the vendor kept failing with 5xx or timeouts after retrying, or its circuit breaker is open
+	ErrVendorDown = errors.New("vendor down")
+)
+
+// outcomes of vendor calls, the outcome label of the vendor request counter
+const (
+	vendorSuccess     = "success"
+	vendorNotFound    = "not_found"
+	vendorRateLimited = "rate_limited"
+	vendorClientError = "client_error"
+	vendorDown        = "vendor_down"
+	vendorCircuitOpen = "circuit_open"
+	vendorCancelled   = "cancelled"
+	// vendorTimeout an attempt timed out, counted as vendor_down
+	vendorTimeout = "timeout"
+)
+
+// VendorRetryPolicy how calls to a vendor are retried. Delays back off exponentially from BaseDelay up to MaxDelay,
+// with jitter
+type VendorRetryPolicy struct {
+	// Attempts total, including the first
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// BreakerThreshold consecutive 5xx or timeouts that trip the circuit breaker open
+	BreakerThreshold int
+	// BreakerCooldown how long the breaker stays open before letting a call through to test the vendor
+	BreakerCooldown time.Duration
+	// RetryTimeouts whether attempts that time out are retried. Off for slow calls, where retrying keeps the caller
+	// waiting for several times the timeout
+	RetryTimeouts bool
+}
+
+var (
+	// drivlyRetryPolicy drivly calls are slow, so few attempts and a long cooldown
+	drivlyRetryPolicy = VendorRetryPolicy{
+		Attempts:         3,
+		BaseDelay:        time.Second,
+		MaxDelay:         10 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  2 * time.Minute,
+		RetryTimeouts:    true,
+	}
+	// drivlyOfferRetryPolicy offers can take minutes, so one that timed out is not tried again
+	drivlyOfferRetryPolicy = VendorRetryPolicy{
+		Attempts:         3,
+		BaseDelay:        time.Second,
+		MaxDelay:         10 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  2 * time.Minute,
+	}
+	vincarioRetryPolicy = VendorRetryPolicy{
+		Attempts:         4,
+		BaseDelay:        500 * time.Millisecond,
+		MaxDelay:         5 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  time.Minute,
+		RetryTimeouts:    true,
+	}
+)
+
+// vendorClient calls a vendor api, retrying 429, 5xx and timeouts per the vendor policy and failing fast while the
+// vendor's circuit breaker is open. Calls stop when the context is done. It calls net/http itself rather than wrapping
+// the shared http.ClientWrapper, whose ExecuteRequest takes no context so vendor calls couldn't be cancelled.
+type vendorClient struct {
+	vendor     string
+	baseURL    string
+	headers    map[string]string
+	httpClient *http.Client
+	policy     VendorRetryPolicy
+	breaker    *circuitBreaker
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+func newVendorClient(vendor, baseURL string, timeout time.Duration, headers map[string]string, policy VendorRetryPolicy) *vendorClient {
+	return &vendorClient{
+		vendor:     vendor,
+		baseURL:    baseURL,
+		headers:    headers,
+		httpClient: &http.Client{Timeout: timeout},
+		policy:     policy,
+		breaker:    newCircuitBreaker(vendor, policy.BreakerThreshold, policy.BreakerCooldown),
+		sleep:      sleepContext,
+	}
+}
+
+// sleepContext sleeps for d, returning early with the context error when it is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Get the response body of a GET to the path. Errors wrap ErrVINNotFound, ErrVendorRateLimited or ErrVendorDown when
+// the vendor responded that way, or the context error when the context is done
+func (vc *vendorClient) Get(ctx context.Context, path string) ([]byte, error) {
+	if !vc.breaker.allow() {
+		appmetrics.VendorRequestCount.WithLabelValues(vc.vendor, vendorCircuitOpen).Inc()
+		return nil, errors.Wrapf(ErrVendorDown, "%s circuit breaker open, not calling %s", vc.vendor, path)
+	}
+
+	var err error
+	for attempt := 0; attempt < vc.policy.Attempts; attempt++ {
+		if attempt > 0 {
+			appmetrics.VendorRetryCount.WithLabelValues(vc.vendor).Inc()
+			if vc.sleep(ctx, vc.backoff(attempt)) != nil {
+				break
+			}
+		}
+		var body []byte
+		var outcome string
+		body, outcome, err = vc.execute(ctx, path)
+		if ctx.Err() != nil {
+			break
+		}
+		switch outcome {
+		case vendorSuccess, vendorNotFound, vendorClientError:
+			// the vendor is up
+			vc.breaker.success()
+			appmetrics.VendorRequestCount.WithLabelValues(vc.vendor, outcome).Inc()
+			return body, err
+		case vendorRateLimited:
+			// the vendor is up, only busy. Closes the breaker after a probe
+			vc.breaker.success()
+		case vendorDown, vendorTimeout:
+			vc.breaker.failure()
+			if !vc.breaker.allow() {
+				appmetrics.VendorRequestCount.WithLabelValues(vc.vendor, vendorCircuitOpen).Inc()
+				return nil, err
+			}
+			if outcome == vendorTimeout && !vc.policy.RetryTimeouts {
+				appmetrics.VendorRequestCount.WithLabelValues(vc.vendor, vendorDown).Inc()
+				return nil, err
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		// cancelled by the caller, says nothing about the vendor
+		vc.breaker.cancelled()
+		appmetrics.VendorRequestCount.WithLabelValues(vc.vendor, vendorCancelled).Inc()
+		return nil, errors.Wrapf(ctx.Err(), "%s call to %s cancelled", vc.vendor, path)
+	}
+	outcome := vendorDown
+	if errors.Is(err, ErrVendorRateLimited) {
+		outcome = vendorRateLimited
+	}
+	appmetrics.VendorRequestCount.WithLabelValues(vc.vendor, outcome).Inc()
+	return nil, err
+}
+
+// execute a single attempt, classifying the result by its outcome
+func (vc *vendorClient) execute(ctx context.Context, path string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, vc.baseURL+path, nil)
+	if err != nil {
+		return nil, vendorClientError, errors.Wrapf(err, "invalid %s request => %s", vc.vendor, path)
+	}
+	for k, v := range vc.headers {
+		req.Header.Set(k, v)
+	}
+	res, err := vc.httpClient.Do(req)
+	if err != nil {
+		// connection error or timeout
+		outcome := vendorDown
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			outcome = vendorTimeout
+		}
+		return nil, outcome, errors.Wrapf(ErrVendorDown, "%s call to %s failed: %s", vc.vendor, path, err.Error())
+	}
+	defer res.Body.Close() // nolint
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, vendorDown, errors.Wrapf(ErrVendorDown, "%s response for %s could not be read: %s", vc.vendor, path, err.Error())
+	}
+	switch code := res.StatusCode; {
+	case code == http.StatusNotFound:
+		return nil, vendorNotFound, errors.Wrapf(ErrVINNotFound, "%s returned 404 for %s", vc.vendor, path)
+	case code == http.StatusTooManyRequests:
+		return nil, vendorRateLimited, errors.Wrapf(ErrVendorRateLimited, "%s returned 429 for %s", vc.vendor, path)
+	case code >= 500:
+		return nil, vendorDown, errors.Wrapf(ErrVendorDown, "%s returned %d for %s", vc.vendor, code, path)
+	case code > 299:
+		return nil, vendorClientError, errors.Errorf("error calling %s api => %s, status code %d with body: %s", vc.vendor, path, code, body)
+	}
+	return body, vendorSuccess, nil
+}
+
+// backoff delay before the attempt, half of it jittered
+func (vc *vendorClient) backoff(attempt int) time.Duration {
+	delay := vc.policy.BaseDelay << (attempt - 1)
+	if delay > vc.policy.MaxDelay || delay <= 0 {
+		delay = vc.policy.MaxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half)
+}
+
+// circuitBreaker opens after threshold consecutive failures and rejects calls until the cooldown passes, then lets a
+// single call through. The breaker closes again if it succeeds and reopens if it fails.
+type circuitBreaker struct {
+	vendor    string
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	// probing a call is testing the vendor after the cooldown
+	probing bool
+}
+
+func newCircuitBreaker(vendor string, threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{vendor: vendor, threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow whether a call may go to the vendor
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openedAt = time.Time{}
+	b.probing = false
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.probing || (b.openedAt.IsZero() && b.failures >= b.threshold) {
+		b.openedAt = b.now()
+		b.probing = false
+		appmetrics.VendorCircuitOpenCount.WithLabelValues(b.vendor).Inc()
+	}
+}
+
+// cancelled a call was cancelled before the vendor answered, lets another call probe the vendor
+func (b *circuitBreaker) cancelled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestVendorClient vendor client against a server responding with the status codes in order, repeating the last
+func newTestVendorClient(t *testing.T, policy VendorRetryPolicy, codes ...int) (*vendorClient, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		call := int(calls.Add(1))
+		w.WriteHeader(codes[min(call, len(codes))-1])
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+
+	vc := newVendorClient("test", server.URL, time.Second, nil, policy)
+	vc.sleep = func(context.Context, time.Duration) error { return nil }
+	return vc, calls
+}
+
+var testRetryPolicy = VendorRetryPolicy{
+	Attempts:         3,
+	BaseDelay:        time.Millisecond,
+	MaxDelay:         time.Millisecond,
+	BreakerThreshold: 5,
+	BreakerCooldown:  time.Minute,
+}
+
+func Test_vendorClient_Get(t *testing.T) {
+	tests := []struct {
+		name      string
+		codes     []int
+		wantErr   error
+		wantCalls int32
+	}{
+		{name: "success", codes: []int{200}, wantCalls: 1},
+		{name: "retries 5xx", codes: []int{503, 500, 200}, wantCalls: 3},
+		{name: "vendor down after retries", codes: []int{502}, wantErr: ErrVendorDown, wantCalls: 3},
+		{name: "rate limited after retries", codes: []int{429}, wantErr: ErrVendorRateLimited, wantCalls: 3},
+		{name: "not found is not retried", codes: []int{404}, wantErr: ErrVINNotFound, wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc, calls := newTestVendorClient(t, testRetryPolicy, tt.codes...)
+
+			body, err := vc.Get(context.Background(), "/vin")
+
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"ok":true}`, string(body))
+		})
+	}
+}
+
+func Test_vendorClient_Get_clientError(t *testing.T) {
+	vc, calls := newTestVendorClient(t, testRetryPolicy, 400)
+
+	_, err := vc.Get(context.Background(), "/vin")
+
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrVendorDown))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func Test_vendorClient_circuitBreaker(t *testing.T) {
+	policy := testRetryPolicy
+	policy.BreakerThreshold = 4
+	// two failing calls of 3 attempts trip the breaker on the fourth failure, the probe after the cooldown succeeds
+	vc, calls := newTestVendorClient(t, policy, 500, 500, 500, 500, 200)
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	vc.breaker.now = func() time.Time { return now }
+
+	_, err := vc.Get(context.Background(), "/vin")
+	assert.True(t, errors.Is(err, ErrVendorDown))
+	_, err = vc.Get(context.Background(), "/vin")
+	assert.True(t, errors.Is(err, ErrVendorDown))
+	assert.Equal(t, int32(4), calls.Load(), "stops retrying once the breaker opens")
+
+	_, err = vc.Get(context.Background(), "/vin")
+	assert.True(t, errors.Is(err, ErrVendorDown))
+	assert.Equal(t, int32(4), calls.Load(), "fails fast while open")
+
+	now = now.Add(policy.BreakerCooldown)
+	_, err = vc.Get(context.Background(), "/vin")
+	require.NoError(t, err)
+	_, err = vc.Get(context.Background(), "/vin")
+	require.NoError(t, err)
+	assert.Equal(t, int32(6), calls.Load(), "closes after a successful probe")
+}
+
+func Test_vendorClient_Get_cancelled(t *testing.T) {
+	vc, calls := newTestVendorClient(t, testRetryPolicy, 503)
+	ctx, cancel := context.WithCancel(context.Background())
+	vc.sleep = func(context.Context, time.Duration) error {
+		cancel()
+		return context.Canceled
+	}
+
+	_, err := vc.Get(ctx, "/vin")
+
+	assert.True(t, errors.Is(err, context.Canceled), "got %v", err)
+	assert.Equal(t, int32(1), calls.Load(), "no retries once cancelled")
+}
+
+func Test_vendorClient_Get_timeout(t *testing.T) {
+	for name, tc := range map[string]struct {
+		retryTimeouts bool
+		wantCalls     int32
+	}{
+		"retried":     {retryTimeouts: true, wantCalls: 3},
+		"not retried": {retryTimeouts: false, wantCalls: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := &atomic.Int32{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				time.Sleep(50 * time.Millisecond)
+			}))
+			t.Cleanup(server.Close)
+			policy := testRetryPolicy
+			policy.RetryTimeouts = tc.retryTimeouts
+			vc := newVendorClient("test", server.URL, 10*time.Millisecond, nil, policy)
+			vc.sleep = func(context.Context, time.Duration) error { return nil }
+
+			_, err := vc.Get(context.Background(), "/vin")
+
+			assert.True(t, errors.Is(err, ErrVendorDown), "got %v", err)
+			assert.Equal(t, tc.wantCalls, calls.Load())
+		})
+	}
+}
+
+func Test_vendorClient_circuitBreaker_rateLimitedProbe(t *testing.T) {
+	policy := testRetryPolicy
+	policy.BreakerThreshold = 3
+	vc, calls := newTestVendorClient(t, policy, 500, 500, 500, 429, 429, 429, 200)
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	vc.breaker.now = func() time.Time { return now }
+
+	_, err := vc.Get(context.Background(), "/vin")
+	assert.True(t, errors.Is(err, ErrVendorDown))
+	_, err = vc.Get(context.Background(), "/vin")
+	assert.True(t, errors.Is(err, ErrVendorDown), "open")
+	assert.Equal(t, int32(3), calls.Load())
+
+	now = now.Add(policy.BreakerCooldown)
+	_, err = vc.Get(context.Background(), "/vin")
+	assert.True(t, errors.Is(err, ErrVendorRateLimited), "half open probe rate limited, got %v", err)
+	assert.Equal(t, int32(6), calls.Load())
+
+	_, err = vc.Get(context.Background(), "/vin")
+	require.NoError(t, err, "a rate limited probe doesn't keep the breaker open")
+	assert.Equal(t, int32(7), calls.Load())
+}
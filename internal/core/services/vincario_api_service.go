@@ -1,29 +1,28 @@
 package services
 
 import (
+	"context"
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
-	"io"
 	"time"
 
 	core "github.com/DIMO-Network/valuations-api/internal/core/models"
 
 	"github.com/DIMO-Network/valuations-api/internal/config"
 
-	"github.com/DIMO-Network/shared/pkg/http"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
 
 //go:generate mockgen -source vincario_api_service.go -destination mocks/vincario_api_service_mock.go -package mock_services
 type VincarioAPIService interface {
-	GetMarketValuation(vin string) (*core.VincarioMarketValueResponse, error)
+	GetMarketValuation(ctx context.Context, vin string) (*core.VincarioMarketValueResponse, error)
 }
 
 type vincarioAPIService struct {
 	settings      *config.Settings
-	httpClientVIN http.ClientWrapper
+	httpClientVIN *vendorClient
 	log           *zerolog.Logger
 }
 
@@ -31,25 +30,19 @@ func NewVincarioAPIService(settings *config.Settings, log *zerolog.Logger) Vinca
 	if settings.VincarioAPIURL == "" || settings.VincarioAPISecret == "" {
 		panic("Vincario configuration not set")
 	}
-	hcwv, _ := http.NewClientWrapper(settings.VincarioAPIURL, "", 10*time.Second, nil, false)
-
 	return &vincarioAPIService{
 		settings:      settings,
-		httpClientVIN: hcwv,
+		httpClientVIN: newVendorClient(VincarioProvider, settings.VincarioAPIURL, 10*time.Second, nil, vincarioRetryPolicy),
 		log:           log,
 	}
 }
 
-func (va *vincarioAPIService) GetMarketValuation(vin string) (*core.VincarioMarketValueResponse, error) {
+func (va *vincarioAPIService) GetMarketValuation(ctx context.Context, vin string) (*core.VincarioMarketValueResponse, error) {
 	id := "vehicle-market-value"
 
 	urlPath := vincarioPathBuilder(vin, id, va.settings.VincarioAPIKey, va.settings.VincarioAPISecret)
 	// url with api access
-	resp, err := va.httpClientVIN.ExecuteRequest(urlPath, "GET", nil)
-	if err != nil {
-		return nil, err
-	}
-	bodyBytes, err := io.ReadAll(resp.Body)
+	bodyBytes, err := va.httpClientVIN.Get(ctx, urlPath)
 	if err != nil {
 		return nil, err
 	}
@@ -59,10 +52,9 @@ func (va *vincarioAPIService) GetMarketValuation(vin string) (*core.VincarioMark
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if data.MarketPrice.Europe.PriceAvg == 0 && data.MarketPrice.NorthAmerica.PriceAvg == 0 {
-		return nil, fmt.Errorf("invalid valuation with 0 value returned - %s", string(bodyBytes))
+		return nil, errors.Wrapf(ErrVINNotFound, "invalid valuation with 0 value returned - %s", string(bodyBytes))
 	}
 
 	return &data, nil
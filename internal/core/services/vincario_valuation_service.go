@@ -18,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/segmentio/ksuid"
+	"github.com/tidwall/gjson"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -26,59 +27,95 @@ import (
 //go:generate mockgen -source vincario_valuation_service.go -destination mocks/vincario_valuation_service_mock.go
 
 type VincarioValuationService interface {
-	PullValuation(ctx context.Context, tokenID uint64, vin string) (core.DataPullStatusEnum, error)
+	ValuationProvider
 }
 
 type vincarioValuationService struct {
-	dbs         func() *db.ReaderWriter
-	log         *zerolog.Logger
-	vincarioSvc VincarioAPIService
-	identityAPI gateways.IdentityAPI
+	dbs          func() *db.ReaderWriter
+	log          *zerolog.Logger
+	vincarioSvc  VincarioAPIService
+	identityAPI  gateways.IdentityAPI
+	locationSvc  LocationService
+	blending     *PriceBlending
+	batteryCurve *BatteryAdjustmentCurve
 }
 
-func NewVincarioValuationService(DBS func() *db.ReaderWriter, log *zerolog.Logger, settings *config.Settings, identityAPI gateways.IdentityAPI) VincarioValuationService {
+func NewVincarioValuationService(DBS func() *db.ReaderWriter, log *zerolog.Logger, settings *config.Settings, identityAPI gateways.IdentityAPI,
+	locationSvc LocationService, blending *PriceBlending, batteryCurve *BatteryAdjustmentCurve) VincarioValuationService {
 	return &vincarioValuationService{
-		dbs:         DBS,
-		log:         log,
-		vincarioSvc: NewVincarioAPIService(settings, log),
-		identityAPI: identityAPI,
+		dbs:          DBS,
+		log:          log,
+		vincarioSvc:  NewVincarioAPIService(settings, log),
+		identityAPI:  identityAPI,
+		locationSvc:  locationSvc,
+		blending:     blending,
+		batteryCurve: batteryCurve,
 	}
 }
 
-// PullValuation ideally we pass country code into here
-func (d *vincarioValuationService) PullValuation(ctx context.Context, tokenID uint64, vin string) (core.DataPullStatusEnum, error) {
-	const repullWindow = time.Hour * 24 * 30 // one month
+func (d *vincarioValuationService) Name() string {
+	return VincarioProvider
+}
+
+func (d *vincarioValuationService) MetadataColumn() string {
+	return models.ValuationColumns.VincarioMetadata
+}
+
+// SupportedCountries vincario market values are for the european market
+func (d *vincarioValuationService) SupportedCountries() []string {
+	return strings.Split(EuropeanCountries, ",")
+}
+
+func (d *vincarioValuationService) RepullWindow() time.Duration {
+	return time.Hour * 24 * 30 // one month
+}
+
+// PullValuation pulls the vincario market value for the VIN, which country to use vincario for is decided by the provider
+// routing. Vincario values by VIN only, the telemetry is used for the vehicle location recorded with the request.
+func (d *vincarioValuationService) PullValuation(ctx context.Context, tokenID uint64, vin string, telemetry core.VehicleTelemetry) (core.DataPullStatusEnum, error) {
 	if len(vin) != 17 {
 		return core.ErrorDataPullStatus, errors.Errorf("invalid VIN %s", vin)
 	}
 
 	// make sure userdevice exists
-	vehicle, err := d.identityAPI.GetVehicle(tokenID)
+	vehicle, err := d.identityAPI.GetVehicle(ctx, tokenID)
 	if err != nil {
 		return core.ErrorDataPullStatus, err
 	}
-	// do not pull for USA
-	gloc, _ := models.GeodecodedLocations(models.GeodecodedLocationWhere.TokenID.EQ(int64(tokenID))).One(ctx, d.dbs().Reader)
-	countryCode := ""
-	if gloc != nil {
-		countryCode = gloc.Country.String
-	}
-	if strings.EqualFold(countryCode, "USA") {
-		return core.SkippedDataPullStatus, nil
-	}
+	localLog := d.log.With().Str("vin", vin).Str("definition_id", vehicle.Definition.ID).Uint64("token_id", tokenID).Logger()
 
 	// check repull window
 	existingPricingData, _ := models.Valuations(
 		models.ValuationWhere.Vin.EQ(vin),
 		models.ValuationWhere.VincarioMetadata.IsNotNull(),
 		qm.OrderBy("updated_at desc"), qm.Limit(1)).
-		One(context.Background(), d.dbs().Writer)
+		One(ctx, d.dbs().Writer)
 
 	// just return if already pulled recently for this VIN, but still need to insert never pulled vin - should be uncommon scenario
-	if existingPricingData != nil && existingPricingData.UpdatedAt.Add(repullWindow).After(time.Now()) {
+	if existingPricingData != nil && existingPricingData.UpdatedAt.Add(d.RepullWindow()).After(time.Now()) {
+		localLog.Info().Msgf("already pulled vincario data for vin %s, skipping", vin)
 		return core.SkippedDataPullStatus, nil
 	}
 
+	// location is only recorded for reference, vincario does not use it
+	reqData := core.ValuationRequestData{}
+	location, err := d.locationSvc.GetGeoDecodedLocation(ctx, telemetry.Signals, tokenID)
+	if err != nil {
+		localLog.Warn().Err(err).Msg("could not get geo decoded location, continuing without")
+	} else {
+		reqData.ZipCode = &location.PostalCode
+		reqData.CountryCode = &location.CountryCode
+	}
+	// vincario market prices don't account for EV battery degradation, adjust for it
+	def, err := d.identityAPI.GetDefinition(ctx, vehicle.Definition.ID)
+	if err != nil {
+		localLog.Warn().Err(err).Msg("could not get device definition, skipping the EV battery adjustment")
+	} else if telemetry.ConditionSignals == nil {
+		localLog.Warn().Msg("no telemetry battery signals, skipping the EV battery adjustment")
+	} else {
+		reqData.Battery = d.batteryCurve.BatteryHealth(def, telemetry.ConditionSignals, time.Now())
+	}
+
 	externalVinData := &models.Valuation{
 		ID:           ksuid.New().String(),
 		DefinitionID: null.StringFrom(vehicle.Definition.ID),
@@ -86,8 +123,9 @@ func (d *vincarioValuationService) PullValuation(ctx context.Context, tokenID ui
 		// at some point change the db datatype to bigint
 		TokenID: types.NewNullDecimal(decimal.New(int64(tokenID), 0)),
 	}
+	_ = externalVinData.RequestMetadata.Marshal(reqData)
 
-	valuation, err := d.vincarioSvc.GetMarketValuation(vin)
+	valuation, err := d.vincarioSvc.GetMarketValuation(ctx, vin)
 	if err != nil {
 		return core.ErrorDataPullStatus, errors.Wrap(err, "error pulling market data from vincario")
 	}
@@ -96,6 +134,13 @@ func (d *vincarioValuationService) PullValuation(ctx context.Context, tokenID ui
 	if err != nil {
 		return core.ErrorDataPullStatus, errors.Wrap(err, "error marshalling vincario responset")
 	}
+	countryCode := ""
+	if reqData.CountryCode != nil {
+		countryCode = *reqData.CountryCode
+	}
+	if valSet := d.ProjectValuation(externalVinData, countryCode); valSet != nil {
+		setValuationColumns(externalVinData, valSet)
+	}
 
 	err = externalVinData.Insert(ctx, d.dbs().Writer, boil.Infer())
 	if err != nil {
@@ -104,3 +149,78 @@ func (d *vincarioValuationService) PullValuation(ctx context.Context, tokenID ui
 
 	return core.PulledValuationVincarioStatus, nil
 }
+
+// ProjectValuation builds the valuation set from the vincario market value response, supports the europe and north
+// america markets. Trade-in is the price below market mean and retail the price above it. Prices are left in the
+// vincario currency, callers convert them with the FxRateService.
+func (d *vincarioValuationService) ProjectValuation(valuation *models.Valuation, countryCode string) *core.ValuationSet {
+	if !valuation.VincarioMetadata.Valid {
+		return nil
+	}
+	valSet := core.ValuationSet{
+		Updated:       valuation.UpdatedAt.Format(time.RFC3339),
+		Vendor:        VincarioProvider,
+		TradeInSource: VincarioProvider,
+		RetailSource:  VincarioProvider,
+		// odometer is the market average
+		OdometerMeasurementType: core.Market,
+	}
+
+	valJSON := valuation.VincarioMetadata.JSON
+	requestJSON := valuation.RequestMetadata.JSON
+	odometerRegion := vincarioOdometerRegion(valJSON)
+	odometerMarket := odometerRegion.Get("odometer_avg")
+
+	if odometerMarket.Exists() {
+		valSet.Mileage = int(odometerMarket.Int())
+		valSet.Odometer = int(odometerMarket.Int())
+		valSet.OdometerUnit = normalizeOdometerUnit(odometerRegion.Get("odometer_unit").String())
+	}
+	requestZipCode := gjson.GetBytes(requestJSON, "zipCode")
+	if !requestZipCode.Exists() {
+		// older requests used postalCode
+		requestZipCode = gjson.GetBytes(requestJSON, "postalCode")
+	}
+	if requestZipCode.Exists() {
+		valSet.ZipCode = requestZipCode.String()
+	}
+	priceRegion := gjson.GetBytes(valJSON, "market_price.europe")
+	if !priceRegion.Exists() {
+		priceRegion = gjson.GetBytes(valJSON, "market_price.north_america")
+	}
+	// vincario Trade-In - just using the price below mkt mean
+	valSet.TradeIn = int(priceRegion.Get("price_below").Float())
+	valSet.TradeInAverage = valSet.TradeIn
+	// vincario Retail - just using the price above mkt mean
+	valSet.Retail = int(priceRegion.Get("price_above").Float())
+	valSet.RetailAverage = valSet.Retail
+
+	valSet.Currency = priceRegion.Get("price_currency").String()
+
+	// display price is blended from the market listings, or the market average
+	blend := BlendInput{
+		VendorPrice: priceRegion.Get("price_avg").Float(),
+		Stdev:       priceRegion.Get("price_stdev").Float(),
+	}
+	for _, record := range gjson.GetBytes(valJSON, "records").Array() {
+		price := record.Get("price").Float()
+		if price > 0 && record.Get("price_currency").String() == valSet.Currency {
+			blend.Points = append(blend.Points, PricePoint{Source: record.Get("market").String(), Value: price})
+		}
+	}
+	strategy := d.blending.ForCountry(countryCode)
+	valSet.UserDisplayPrice = int(strategy.Blend(blend))
+	valSet.BlendingStrategy = strategy.Name()
+	applyBatteryAdjustment(&valSet, requestJSON)
+
+	return &valSet
+}
+
+// vincarioOdometerRegion the market odometer of the region vincario valued in, vincario now suports two markets
+func vincarioOdometerRegion(valJSON []byte) gjson.Result {
+	odometerRegion := gjson.GetBytes(valJSON, "market_odometer.europe")
+	if !odometerRegion.Exists() {
+		odometerRegion = gjson.GetBytes(valJSON, "market_odometer.north_america")
+	}
+	return odometerRegion
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_vincarioValuationService_ProjectValuation(t *testing.T) {
+	valuation := setupCreateValuationsData(t, 123, ksuid.New().String(), "vinny", map[string][]byte{
+		"VincarioMetadata": []byte(testVincarioValuationJSON),
+		"RequestMetadata":  []byte(`{"zipCode":"10115","countryCode":"DE"}`),
+	}, nil)
+
+	valSet := (&vincarioValuationService{}).ProjectValuation(valuation, "DEU")
+
+	require.NotNil(t, valSet)
+	assert.Equal(t, VincarioProvider, valSet.Vendor)
+	assert.Equal(t, "10115", valSet.ZipCode)
+	assert.Equal(t, "EUR", valSet.Currency)
+	assert.Equal(t, 32115, valSet.UserDisplayPrice)
+	assert.Equal(t, core.Market, valSet.OdometerMeasurementType)
+}
+
+func Test_vincarioValuationService_ProjectValuation_legacyPostalCode(t *testing.T) {
+	valuation := setupCreateValuationsData(t, 123, ksuid.New().String(), "vinny", map[string][]byte{
+		"VincarioMetadata": []byte(testVincarioValuationJSON),
+		"RequestMetadata":  []byte(`{"postalCode":"75001"}`),
+	}, nil)
+
+	valSet := (&vincarioValuationService{}).ProjectValuation(valuation, "FRA")
+
+	require.NotNil(t, valSet)
+	assert.Equal(t, "75001", valSet.ZipCode)
+}
+
+func Test_vincarioValuationService_ProjectValuation_noVincarioData(t *testing.T) {
+	valuation := setupCreateValuationsData(t, 123, ksuid.New().String(), "vinny", map[string][]byte{
+		"DrivlyPricingMetadata": []byte(testDrivlyPricingJSON),
+	}, nil)
+
+	assert.Nil(t, (&vincarioValuationService{}).ProjectValuation(valuation, "USA"))
+}
@@ -0,0 +1,71 @@
+package units
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DistanceUnit unit an odometer or mileage is in
+type DistanceUnit string
+
+const (
+	Kilometers DistanceUnit = "km"
+	// Miles serialised as "miles", what the api has always returned for drivly valuations. "mi" is accepted on parse
+	Miles DistanceUnit = "miles"
+)
+
+const milesPerKilometer = 0.621371
+
+func (u DistanceUnit) String() string {
+	return string(u)
+}
+
+// ParseDistanceUnit km or mi, also accepts the spellings vendors use, eg. kilometers or miles. Case insensitive
+func ParseDistanceUnit(unit string) (DistanceUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "km", "kms", "kilometer", "kilometers", "kilometre", "kilometres":
+		return Kilometers, nil
+	case "mi", "mile", "miles":
+		return Miles, nil
+	}
+	return "", errors.Errorf("unknown distance unit %q, expected km or mi", unit)
+}
+
+// Distance a distance in a unit
+type Distance struct {
+	Value float64
+	Unit  DistanceUnit
+}
+
+// KilometersOf the distance of value km
+func KilometersOf(value float64) Distance {
+	return Distance{Value: value, Unit: Kilometers}
+}
+
+// MilesOf the distance of value mi
+func MilesOf(value float64) Distance {
+	return Distance{Value: value, Unit: Miles}
+}
+
+// Kilometers the distance in km
+func (d Distance) Kilometers() float64 {
+	return d.In(Kilometers).Value
+}
+
+// Miles the distance in mi
+func (d Distance) Miles() float64 {
+	return d.In(Miles).Value
+}
+
+// In the distance converted to the unit
+func (d Distance) In(unit DistanceUnit) Distance {
+	switch {
+	case d.Unit == unit:
+		return d
+	case unit == Miles:
+		return MilesOf(d.Value * milesPerKilometer)
+	default:
+		return KilometersOf(d.Value / milesPerKilometer)
+	}
+}
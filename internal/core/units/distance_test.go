@@ -0,0 +1,41 @@
+package units
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDistanceUnit(t *testing.T) {
+	for unit, want := range map[string]DistanceUnit{
+		"km":         Kilometers,
+		"KM":         Kilometers,
+		"kilometers": Kilometers,
+		"mi":         Miles,
+		"miles":      Miles,
+		" Miles ":    Miles,
+	} {
+		got, err := ParseDistanceUnit(unit)
+		require.NoError(t, err, unit)
+		assert.Equal(t, want, got, unit)
+	}
+
+	_, err := ParseDistanceUnit("furlongs")
+	assert.Error(t, err)
+	_, err = ParseDistanceUnit("")
+	assert.Error(t, err)
+}
+
+func TestDistanceUnit_String(t *testing.T) {
+	// wire values consumers of the http and grpc apis depend on
+	assert.Equal(t, "km", Kilometers.String())
+	assert.Equal(t, "miles", Miles.String())
+}
+
+func TestDistance_In(t *testing.T) {
+	assert.InDelta(t, 62137.1, KilometersOf(100000).Miles(), 0.001)
+	assert.InDelta(t, 100000, MilesOf(62137.1).Kilometers(), 0.001)
+	assert.Equal(t, MilesOf(1000), MilesOf(1000).In(Miles))
+	assert.Equal(t, Kilometers, MilesOf(1000).In(Kilometers).Unit)
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- reference exchange rates, euro based like the ECB publishes them: rate is units of currency per 1 EUR
+create table valuations_api.fx_rates
+(
+    currency   char(3)                  not null,
+    rate_date  date                     not null,
+    rate       double precision         not null,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    constraint fx_rates_pk primary key (currency, rate_date)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+drop table valuations_api.fx_rates;
+-- +goose StatementEnd
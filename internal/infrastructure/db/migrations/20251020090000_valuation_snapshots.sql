@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+-- daily totals of the latest valuation of every vehicle in USD. dimension is network (dimension_key empty),
+-- definition (dimension_key is the definition id) or country (dimension_key is the alpha-3 country code)
+create table valuations_api.valuation_snapshots
+(
+    snapshot_date date                     not null,
+    dimension     text                     not null,
+    dimension_key text                     not null,
+    total_usd     double precision         not null,
+    vehicle_count integer                  not null,
+    created_at    timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    constraint valuation_snapshots_pk primary key (snapshot_date, dimension, dimension_key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+drop table valuations_api.valuation_snapshots;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = valuations_api, public;
+-- projected values written at pull time, the vendor json is kept for audit. null for offers and rows not backfilled yet
+ALTER TABLE valuations_api.valuations
+    ADD COLUMN vendor             text,
+    ADD COLUMN trade_in           integer,
+    ADD COLUMN retail             integer,
+    ADD COLUMN user_display_price integer,
+    ADD COLUMN currency           char(3),
+    ADD COLUMN mileage            integer,
+    ADD COLUMN odometer_type      text,
+    ADD COLUMN odometer_unit      text,
+    ADD COLUMN zip_code           text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = valuations_api, public;
+ALTER TABLE valuations_api.valuations
+    DROP COLUMN vendor,
+    DROP COLUMN trade_in,
+    DROP COLUMN retail,
+    DROP COLUMN user_display_price,
+    DROP COLUMN currency,
+    DROP COLUMN mileage,
+    DROP COLUMN odometer_type,
+    DROP COLUMN odometer_unit,
+    DROP COLUMN zip_code;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = valuations_api, public;
+ALTER TABLE valuations_api.valuations
+    ADD COLUMN trade_in_clean integer,
+    ADD COLUMN trade_in_rough integer,
+    ADD COLUMN retail_clean   integer,
+    ADD COLUMN retail_rough   integer,
+    -- book values behind a blended price, {"tradeIn": [...], "retail": [...]}
+    ADD COLUMN value_sources  jsonb,
+    -- version of the projection the columns were written with, rows behind the api's are re-projected by
+    -- backfill-valuation-columns and read from the vendor json until then
+    ADD COLUMN columns_version integer;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = valuations_api, public;
+ALTER TABLE valuations_api.valuations
+    DROP COLUMN trade_in_clean,
+    DROP COLUMN trade_in_rough,
+    DROP COLUMN retail_clean,
+    DROP COLUMN retail_rough,
+    DROP COLUMN value_sources,
+    DROP COLUMN columns_version;
+-- +goose StatementEnd
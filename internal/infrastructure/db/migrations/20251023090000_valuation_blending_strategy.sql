@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = valuations_api, public;
+-- valuation columns version 3, backfill-valuation-columns re-projects existing rows to fill it in
+-- strategy the user_display_price was blended with, null for valuations from before it was recorded
+ALTER TABLE valuations_api.valuations ADD COLUMN blending_strategy text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = valuations_api, public;
+ALTER TABLE valuations_api.valuations DROP COLUMN blending_strategy;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = valuations_api, public;
+-- valuation columns version 4, backfill-valuation-columns re-projects existing rows to fill it in
+-- trade_in and retail are now in the condition tier, the averages are kept separately. null for rows written before,
+-- where they were the average
+ALTER TABLE valuations_api.valuations
+    ADD COLUMN trade_in_average integer,
+    ADD COLUMN retail_average   integer,
+    ADD COLUMN condition_tier   text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = valuations_api, public;
+ALTER TABLE valuations_api.valuations
+    DROP COLUMN trade_in_average,
+    DROP COLUMN retail_average,
+    DROP COLUMN condition_tier;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = valuations_api, public;
+-- valuation columns version 5, backfill-valuation-columns re-projects existing rows to fill it in
+-- EV battery health adjustment included in the prices, null for other powertrains or when the health is unknown
+ALTER TABLE valuations_api.valuations
+    ADD COLUMN battery_adjustment      integer,
+    ADD COLUMN battery_state_of_health double precision;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = valuations_api, public;
+ALTER TABLE valuations_api.valuations
+    DROP COLUMN battery_adjustment,
+    DROP COLUMN battery_state_of_health;
+-- +goose StatementEnd
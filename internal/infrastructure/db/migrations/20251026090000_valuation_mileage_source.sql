@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+SELECT 'up SQL query';
+SET search_path = valuations_api, public;
+-- valuation columns version 6, backfill-valuation-columns re-projects existing rows to fill it in
+-- telemetry, extrapolated or country_average. null for rows from before it was recorded
+ALTER TABLE valuations_api.valuations ADD COLUMN mileage_source text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'down SQL query';
+SET search_path = valuations_api, public;
+ALTER TABLE valuations_api.valuations DROP COLUMN mileage_source;
+-- +goose StatementEnd
@@ -4,9 +4,13 @@
 package models
 
 var TableNames = struct {
+	FxRates            string
 	GeodecodedLocation string
+	ValuationSnapshots string
 	Valuations         string
 }{
+	FxRates:            "fx_rates",
 	GeodecodedLocation: "geodecoded_location",
+	ValuationSnapshots: "valuation_snapshots",
 	Valuations:         "valuations",
 }
@@ -37,6 +37,28 @@ type Valuation struct {
 	VincarioMetadata      null.JSON         `boil:"vincario_metadata" json:"vincario_metadata,omitempty" toml:"vincario_metadata" yaml:"vincario_metadata,omitempty"`
 	DefinitionID          null.String       `boil:"definition_id" json:"definition_id,omitempty" toml:"definition_id" yaml:"definition_id,omitempty"`
 	TokenID               types.NullDecimal `boil:"token_id" json:"token_id,omitempty" toml:"token_id" yaml:"token_id,omitempty"`
+	Vendor                null.String       `boil:"vendor" json:"vendor,omitempty" toml:"vendor" yaml:"vendor,omitempty"`
+	TradeIn               null.Int          `boil:"trade_in" json:"trade_in,omitempty" toml:"trade_in" yaml:"trade_in,omitempty"`
+	Retail                null.Int          `boil:"retail" json:"retail,omitempty" toml:"retail" yaml:"retail,omitempty"`
+	UserDisplayPrice      null.Int          `boil:"user_display_price" json:"user_display_price,omitempty" toml:"user_display_price" yaml:"user_display_price,omitempty"`
+	Currency              null.String       `boil:"currency" json:"currency,omitempty" toml:"currency" yaml:"currency,omitempty"`
+	Mileage               null.Int          `boil:"mileage" json:"mileage,omitempty" toml:"mileage" yaml:"mileage,omitempty"`
+	OdometerType          null.String       `boil:"odometer_type" json:"odometer_type,omitempty" toml:"odometer_type" yaml:"odometer_type,omitempty"`
+	OdometerUnit          null.String       `boil:"odometer_unit" json:"odometer_unit,omitempty" toml:"odometer_unit" yaml:"odometer_unit,omitempty"`
+	ZipCode               null.String       `boil:"zip_code" json:"zip_code,omitempty" toml:"zip_code" yaml:"zip_code,omitempty"`
+	TradeInClean          null.Int          `boil:"trade_in_clean" json:"trade_in_clean,omitempty" toml:"trade_in_clean" yaml:"trade_in_clean,omitempty"`
+	TradeInRough          null.Int          `boil:"trade_in_rough" json:"trade_in_rough,omitempty" toml:"trade_in_rough" yaml:"trade_in_rough,omitempty"`
+	RetailClean           null.Int          `boil:"retail_clean" json:"retail_clean,omitempty" toml:"retail_clean" yaml:"retail_clean,omitempty"`
+	RetailRough           null.Int          `boil:"retail_rough" json:"retail_rough,omitempty" toml:"retail_rough" yaml:"retail_rough,omitempty"`
+	ValueSources          null.JSON         `boil:"value_sources" json:"value_sources,omitempty" toml:"value_sources" yaml:"value_sources,omitempty"`
+	BlendingStrategy      null.String       `boil:"blending_strategy" json:"blending_strategy,omitempty" toml:"blending_strategy" yaml:"blending_strategy,omitempty"`
+	TradeInAverage        null.Int          `boil:"trade_in_average" json:"trade_in_average,omitempty" toml:"trade_in_average" yaml:"trade_in_average,omitempty"`
+	RetailAverage         null.Int          `boil:"retail_average" json:"retail_average,omitempty" toml:"retail_average" yaml:"retail_average,omitempty"`
+	ConditionTier         null.String       `boil:"condition_tier" json:"condition_tier,omitempty" toml:"condition_tier" yaml:"condition_tier,omitempty"`
+	BatteryAdjustment     null.Int          `boil:"battery_adjustment" json:"battery_adjustment,omitempty" toml:"battery_adjustment" yaml:"battery_adjustment,omitempty"`
+	BatteryStateOfHealth  null.Float64      `boil:"battery_state_of_health" json:"battery_state_of_health,omitempty" toml:"battery_state_of_health" yaml:"battery_state_of_health,omitempty"`
+	MileageSource         null.String       `boil:"mileage_source" json:"mileage_source,omitempty" toml:"mileage_source" yaml:"mileage_source,omitempty"`
+	ColumnsVersion        null.Int          `boil:"columns_version" json:"columns_version,omitempty" toml:"columns_version" yaml:"columns_version,omitempty"`
 
 	R *valuationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L valuationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -55,6 +77,28 @@ var ValuationColumns = struct {
 	VincarioMetadata      string
 	DefinitionID          string
 	TokenID               string
+	Vendor                string
+	TradeIn               string
+	Retail                string
+	UserDisplayPrice      string
+	Currency              string
+	Mileage               string
+	OdometerType          string
+	OdometerUnit          string
+	ZipCode               string
+	TradeInClean          string
+	TradeInRough          string
+	RetailClean           string
+	RetailRough           string
+	ValueSources          string
+	BlendingStrategy      string
+	TradeInAverage        string
+	RetailAverage         string
+	ConditionTier         string
+	BatteryAdjustment     string
+	BatteryStateOfHealth  string
+	MileageSource         string
+	ColumnsVersion        string
 }{
 	ID:                    "id",
 	DeviceDefinitionID:    "device_definition_id",
@@ -68,6 +112,28 @@ var ValuationColumns = struct {
 	VincarioMetadata:      "vincario_metadata",
 	DefinitionID:          "definition_id",
 	TokenID:               "token_id",
+	Vendor:                "vendor",
+	TradeIn:               "trade_in",
+	Retail:                "retail",
+	UserDisplayPrice:      "user_display_price",
+	Currency:              "currency",
+	Mileage:               "mileage",
+	OdometerType:          "odometer_type",
+	OdometerUnit:          "odometer_unit",
+	ZipCode:               "zip_code",
+	TradeInClean:          "trade_in_clean",
+	TradeInRough:          "trade_in_rough",
+	RetailClean:           "retail_clean",
+	RetailRough:           "retail_rough",
+	ValueSources:          "value_sources",
+	BlendingStrategy:      "blending_strategy",
+	TradeInAverage:        "trade_in_average",
+	RetailAverage:         "retail_average",
+	ConditionTier:         "condition_tier",
+	BatteryAdjustment:     "battery_adjustment",
+	BatteryStateOfHealth:  "battery_state_of_health",
+	MileageSource:         "mileage_source",
+	ColumnsVersion:        "columns_version",
 }
 
 var ValuationTableColumns = struct {
@@ -83,6 +149,28 @@ var ValuationTableColumns = struct {
 	VincarioMetadata      string
 	DefinitionID          string
 	TokenID               string
+	Vendor                string
+	TradeIn               string
+	Retail                string
+	UserDisplayPrice      string
+	Currency              string
+	Mileage               string
+	OdometerType          string
+	OdometerUnit          string
+	ZipCode               string
+	TradeInClean          string
+	TradeInRough          string
+	RetailClean           string
+	RetailRough           string
+	ValueSources          string
+	BlendingStrategy      string
+	TradeInAverage        string
+	RetailAverage         string
+	ConditionTier         string
+	BatteryAdjustment     string
+	BatteryStateOfHealth  string
+	MileageSource         string
+	ColumnsVersion        string
 }{
 	ID:                    "valuations.id",
 	DeviceDefinitionID:    "valuations.device_definition_id",
@@ -96,6 +184,28 @@ var ValuationTableColumns = struct {
 	VincarioMetadata:      "valuations.vincario_metadata",
 	DefinitionID:          "valuations.definition_id",
 	TokenID:               "valuations.token_id",
+	Vendor:                "valuations.vendor",
+	TradeIn:               "valuations.trade_in",
+	Retail:                "valuations.retail",
+	UserDisplayPrice:      "valuations.user_display_price",
+	Currency:              "valuations.currency",
+	Mileage:               "valuations.mileage",
+	OdometerType:          "valuations.odometer_type",
+	OdometerUnit:          "valuations.odometer_unit",
+	ZipCode:               "valuations.zip_code",
+	TradeInClean:          "valuations.trade_in_clean",
+	TradeInRough:          "valuations.trade_in_rough",
+	RetailClean:           "valuations.retail_clean",
+	RetailRough:           "valuations.retail_rough",
+	ValueSources:          "valuations.value_sources",
+	BlendingStrategy:      "valuations.blending_strategy",
+	TradeInAverage:        "valuations.trade_in_average",
+	RetailAverage:         "valuations.retail_average",
+	ConditionTier:         "valuations.condition_tier",
+	BatteryAdjustment:     "valuations.battery_adjustment",
+	BatteryStateOfHealth:  "valuations.battery_state_of_health",
+	MileageSource:         "valuations.mileage_source",
+	ColumnsVersion:        "valuations.columns_version",
 }
 
 // Generated where
@@ -181,6 +291,82 @@ func (w whereHelpertypes_NullDecimal) IsNotNull() qm.QueryMod {
 	return qmhelper.WhereIsNotNull(w.field)
 }
 
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Float64 struct{ field string }
+
+func (w whereHelpernull_Float64) EQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Float64) NEQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Float64) LT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Float64) LTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Float64) GT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Float64) GTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Float64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Float64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Float64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Float64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ValuationWhere = struct {
 	ID                    whereHelperstring
 	DeviceDefinitionID    whereHelpernull_String
@@ -194,6 +380,28 @@ var ValuationWhere = struct {
 	VincarioMetadata      whereHelpernull_JSON
 	DefinitionID          whereHelpernull_String
 	TokenID               whereHelpertypes_NullDecimal
+	Vendor                whereHelpernull_String
+	TradeIn               whereHelpernull_Int
+	Retail                whereHelpernull_Int
+	UserDisplayPrice      whereHelpernull_Int
+	Currency              whereHelpernull_String
+	Mileage               whereHelpernull_Int
+	OdometerType          whereHelpernull_String
+	OdometerUnit          whereHelpernull_String
+	ZipCode               whereHelpernull_String
+	TradeInClean          whereHelpernull_Int
+	TradeInRough          whereHelpernull_Int
+	RetailClean           whereHelpernull_Int
+	RetailRough           whereHelpernull_Int
+	ValueSources          whereHelpernull_JSON
+	BlendingStrategy      whereHelpernull_String
+	TradeInAverage        whereHelpernull_Int
+	RetailAverage         whereHelpernull_Int
+	ConditionTier         whereHelpernull_String
+	BatteryAdjustment     whereHelpernull_Int
+	BatteryStateOfHealth  whereHelpernull_Float64
+	MileageSource         whereHelpernull_String
+	ColumnsVersion        whereHelpernull_Int
 }{
 	ID:                    whereHelperstring{field: "\"valuations_api\".\"valuations\".\"id\""},
 	DeviceDefinitionID:    whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"device_definition_id\""},
@@ -207,6 +415,28 @@ var ValuationWhere = struct {
 	VincarioMetadata:      whereHelpernull_JSON{field: "\"valuations_api\".\"valuations\".\"vincario_metadata\""},
 	DefinitionID:          whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"definition_id\""},
 	TokenID:               whereHelpertypes_NullDecimal{field: "\"valuations_api\".\"valuations\".\"token_id\""},
+	Vendor:                whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"vendor\""},
+	TradeIn:               whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"trade_in\""},
+	Retail:                whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"retail\""},
+	UserDisplayPrice:      whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"user_display_price\""},
+	Currency:              whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"currency\""},
+	Mileage:               whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"mileage\""},
+	OdometerType:          whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"odometer_type\""},
+	OdometerUnit:          whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"odometer_unit\""},
+	ZipCode:               whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"zip_code\""},
+	TradeInClean:          whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"trade_in_clean\""},
+	TradeInRough:          whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"trade_in_rough\""},
+	RetailClean:           whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"retail_clean\""},
+	RetailRough:           whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"retail_rough\""},
+	ValueSources:          whereHelpernull_JSON{field: "\"valuations_api\".\"valuations\".\"value_sources\""},
+	BlendingStrategy:      whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"blending_strategy\""},
+	TradeInAverage:        whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"trade_in_average\""},
+	RetailAverage:         whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"retail_average\""},
+	ConditionTier:         whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"condition_tier\""},
+	BatteryAdjustment:     whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"battery_adjustment\""},
+	BatteryStateOfHealth:  whereHelpernull_Float64{field: "\"valuations_api\".\"valuations\".\"battery_state_of_health\""},
+	MileageSource:         whereHelpernull_String{field: "\"valuations_api\".\"valuations\".\"mileage_source\""},
+	ColumnsVersion:        whereHelpernull_Int{field: "\"valuations_api\".\"valuations\".\"columns_version\""},
 }
 
 // ValuationRels is where relationship names are stored.
@@ -226,9 +456,9 @@ func (*valuationR) NewStruct() *valuationR {
 type valuationL struct{}
 
 var (
-	valuationAllColumns            = []string{"id", "device_definition_id", "vin", "offer_metadata", "edmunds_metadata", "created_at", "updated_at", "drivly_pricing_metadata", "request_metadata", "vincario_metadata", "definition_id", "token_id"}
+	valuationAllColumns            = []string{"id", "device_definition_id", "vin", "offer_metadata", "edmunds_metadata", "created_at", "updated_at", "drivly_pricing_metadata", "request_metadata", "vincario_metadata", "definition_id", "token_id", "vendor", "trade_in", "retail", "user_display_price", "currency", "mileage", "odometer_type", "odometer_unit", "zip_code", "trade_in_clean", "trade_in_rough", "retail_clean", "retail_rough", "value_sources", "blending_strategy", "trade_in_average", "retail_average", "condition_tier", "battery_adjustment", "battery_state_of_health", "mileage_source", "columns_version"}
 	valuationColumnsWithoutDefault = []string{"id", "vin"}
-	valuationColumnsWithDefault    = []string{"device_definition_id", "offer_metadata", "edmunds_metadata", "created_at", "updated_at", "drivly_pricing_metadata", "request_metadata", "vincario_metadata", "definition_id", "token_id"}
+	valuationColumnsWithDefault    = []string{"device_definition_id", "offer_metadata", "edmunds_metadata", "created_at", "updated_at", "drivly_pricing_metadata", "request_metadata", "vincario_metadata", "definition_id", "token_id", "vendor", "trade_in", "retail", "user_display_price", "currency", "mileage", "odometer_type", "odometer_unit", "zip_code", "trade_in_clean", "trade_in_rough", "retail_clean", "retail_rough", "value_sources", "blending_strategy", "trade_in_average", "retail_average", "condition_tier", "battery_adjustment", "battery_state_of_health", "mileage_source", "columns_version"}
 	valuationPrimaryKeyColumns     = []string{"id"}
 	valuationGeneratedColumns      = []string{}
 )
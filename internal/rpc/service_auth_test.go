@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/DIMO-Network/valuations-api/pkg/grpc"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func Test_ServiceAuthInterceptor(t *testing.T) {
+	secret := []byte("shh")
+	interceptor := ServiceAuthInterceptor(secret, "valuations-api", pb.ValuationsService_GetUserDeviceValuation_FullMethodName)
+	protected := &grpc.UnaryServerInfo{FullMethod: pb.ValuationsService_GetUserDeviceValuation_FullMethodName}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return ServiceFromContext(ctx), nil
+	}
+	sign := func(claims jwt.RegisteredClaims, key []byte) context.Context {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+		require.NoError(t, err)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+	valid := jwt.RegisteredClaims{
+		Subject:   "devices-api",
+		Audience:  jwt.ClaimStrings{"valuations-api"},
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		service, err := interceptor(sign(valid, secret), nil, protected, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "devices-api", service)
+	})
+
+	t.Run("unprotected method", func(t *testing.T) {
+		_, err := interceptor(context.Background(), nil,
+			&grpc.UnaryServerInfo{FullMethod: pb.ValuationsService_GetUserDeviceOffer_FullMethodName}, handler)
+		require.NoError(t, err)
+	})
+
+	wrongAudience := valid
+	wrongAudience.Audience = jwt.ClaimStrings{"devices-api"}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noSubject := valid
+	noSubject.Subject = ""
+	for name, ctx := range map[string]context.Context{
+		"no token":       context.Background(),
+		"wrong secret":   sign(valid, []byte("other")),
+		"wrong audience": sign(wrongAudience, secret),
+		"expired":        sign(expired, secret),
+		"no subject":     sign(noSubject, secret),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := interceptor(ctx, nil, protected, handler)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+
+	t.Run("no secret configured", func(t *testing.T) {
+		_, err := ServiceAuthInterceptor(nil, "valuations-api", protected.FullMethod)(sign(valid, secret), nil, protected, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -2,153 +2,154 @@ package rpc
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/DIMO-Network/shared/pkg/db"
+	core "github.com/DIMO-Network/valuations-api/internal/core/models"
 	"github.com/DIMO-Network/valuations-api/internal/core/services"
 	pb "github.com/DIMO-Network/valuations-api/pkg/grpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
-	"github.com/volatiletech/null/v8"
-	"github.com/volatiletech/sqlboiler/v4/queries"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
-// Euro to USD conversion rate, used for calculating the price of the device, hardcoded for now
-const (
-	euroToUsd float64 = 1.10
-)
-
 type valuationsService struct {
 	pb.UnimplementedValuationsServiceServer
 	userDeviceService services.UserDeviceAPIService
-	dbs               func() *db.ReaderWriter
+	portfolio         services.PortfolioService
+	analytics         services.NetworkAnalyticsService
 	logger            *zerolog.Logger
 }
 
 func NewValuationsService(
-	dbs func() *db.ReaderWriter,
 	logger *zerolog.Logger,
 	userDeviceService services.UserDeviceAPIService,
+	portfolio services.PortfolioService,
+	analytics services.NetworkAnalyticsService,
 ) pb.ValuationsServiceServer {
 	return &valuationsService{
-		dbs:               dbs,
 		logger:            logger,
 		userDeviceService: userDeviceService,
+		portfolio:         portfolio,
+		analytics:         analytics,
 	}
 }
 
-func (s *valuationsService) GetAllUserDeviceValuation(ctx context.Context, _ *emptypb.Empty) (*pb.ValuationResponse, error) {
-
-	query := `select sum(evd.retail_price) as total_retail,
-					 sum(evd.vincario_price) as total_vincario
-					 from
-                             (
-								select distinct on (vin) vin, 
-														 drivly_pricing_metadata, 
-														jsonb_path_query(evd.drivly_pricing_metadata, '$.retail.kelley.book')::decimal as retail_price,
-														jsonb_path_query(evd.vincario_metadata, '$.market_price.price_avg')::decimal as vincario_price,
-														created_at
-       							from valuations evd 
-								order by vin, created_at desc
-							) as evd;`
-
-	queryGrowth := `select sum(evd.retail_price) as total_retail,
-					 sum(evd.vincario_price) as total_vincario
-					 from
-						(
-							select distinct on (vin) vin, 
-													drivly_pricing_metadata, 
-													jsonb_path_query(evd.drivly_pricing_metadata, '$.retail.kelley.book')::decimal as retail_price, 
-													jsonb_path_query(evd.vincario_metadata, '$.market_price.price_avg')::decimal as vincario_price,
-													created_at
-							from valuations evd 
-							where created_at > current_date - 7
-							order by vin, created_at desc
-						) as evd;`
-
-	type Result struct {
-		TotalRetail   null.Float64 `boil:"total_retail"`
-		TotalVincario null.Float64 `boil:"total_vincario"`
-	}
-	var total Result
-	var lastWeek Result
-
-	err := queries.Raw(query).Bind(ctx, s.dbs().Reader, &total)
+func (s *valuationsService) GetAllValuations(ctx context.Context, _ *emptypb.Empty) (*pb.ValuationResponse, error) {
+	nv, err := s.analytics.GetNetworkValuation(ctx)
 	if err != nil {
-		s.logger.Err(err).Msg("Database failure retrieving total valuation.")
+		s.logger.Err(err).Msg("Database failure retrieving network valuation.")
 		return nil, status.Error(codes.Internal, "Internal error.")
 	}
 
-	err = queries.Raw(queryGrowth).Bind(ctx, s.dbs().Reader, &lastWeek)
-	if err != nil {
-		s.logger.Err(err).Msg("Database failure retrieving last week valuation.")
-		return nil, status.Error(codes.Internal, "Internal error.")
+	resp := &pb.ValuationResponse{
+		Total:                float32(nv.TotalUSD),
+		SnapshotDate:         nv.SnapshotDate,
+		VehicleCount:         int32(nv.VehicleCount),
+		WeekOverWeekGrowth:   float32Ptr(nv.WeekOverWeekGrowth),
+		MonthOverMonthGrowth: float32Ptr(nv.MonthOverMonthGrowth),
+		ByDefinition:         breakdownsToPb(nv.ByDefinition),
+		ByCountry:            breakdownsToPb(nv.ByCountry),
 	}
-
-	totalValuation := total.TotalRetail.Float64 // 0 by default
-	growthPercentage := 0.0
-
-	if !total.TotalVincario.IsZero() {
-		totalValuation += total.TotalVincario.Float64 * euroToUsd
+	if nv.WeekOverWeekGrowth != nil {
+		resp.GrowthPercentage = float32(*nv.WeekOverWeekGrowth)
 	}
+	return resp, nil
+}
 
-	if totalValuation > 0 {
-		totalLastWeek := lastWeek.TotalRetail.Float64
+// GetAllUserDeviceValuation same as GetAllValuations, kept for existing consumers
+func (s *valuationsService) GetAllUserDeviceValuation(ctx context.Context, req *emptypb.Empty) (*pb.ValuationResponse, error) {
+	return s.GetAllValuations(ctx, req)
+}
 
-		if !lastWeek.TotalVincario.IsZero() {
-			totalLastWeek += lastWeek.TotalVincario.Float64 * euroToUsd
+func breakdownsToPb(breakdowns []core.ValuationBreakdown) []*pb.ValuationBreakdown {
+	pbBreakdowns := make([]*pb.ValuationBreakdown, len(breakdowns))
+	for i, b := range breakdowns {
+		pbBreakdowns[i] = &pb.ValuationBreakdown{
+			Key:          b.Key,
+			Make:         b.Make,
+			Model:        b.Model,
+			Year:         int32(b.Year),
+			Total:        float32(b.TotalUSD),
+			VehicleCount: int32(b.VehicleCount),
 		}
-		growthPercentage = (totalLastWeek / totalValuation) * 100
 	}
+	return pbBreakdowns
+}
 
-	// todo: get an average valuation per vehicle, and multiply for whatever count of vehicles we did not get value for
+func sourcesToPb(sources []core.ValuationSource) []*pb.ValuationSource {
+	pbSources := make([]*pb.ValuationSource, len(sources))
+	for i, src := range sources {
+		pbSources[i] = &pb.ValuationSource{
+			Source:   src.Source,
+			Value:    int32(src.Value),
+			Clean:    int32(src.Clean),
+			Rough:    int32(src.Rough),
+			Included: src.Included,
+		}
+	}
+	return pbSources
+}
 
-	return &pb.ValuationResponse{
-		Total:            float32(totalValuation),
-		GrowthPercentage: float32(growthPercentage),
-	}, nil
+func float32Ptr(f *float64) *float32 {
+	if f == nil {
+		return nil
+	}
+	v := float32(*f)
+	return &v
 }
 
-func (s *valuationsService) GetUserDeviceValuation(_ context.Context, _ *pb.DeviceValuationRequest) (*pb.DeviceValuation, error) {
-
-	return nil, fmt.Errorf("no longer supported, must provide Privilege token")
-
-	//valuations, err := s.userDeviceService.GetValuations(ctx, req.TokenId, "")
-	//
-	//if err != nil {
-	//	return nil, err
-	//}
-	//
-	//rpcValuations := pb.DeviceValuation{
-	//	ValuationSets: make([]*pb.ValuationSet, len(valuations.ValuationSets)),
-	//}
-	//
-	//for i, v := range valuations.ValuationSets {
-	//	rpcValuations.ValuationSets[i] = &pb.ValuationSet{
-	//		Vendor:           v.Vendor,
-	//		Updated:          v.Updated,
-	//		Mileage:          int32(v.Mileage),
-	//		ZipCode:          v.ZipCode,
-	//		TradeInSource:    v.TradeInSource,
-	//		TradeIn:          int32(v.TradeIn),
-	//		TradeInClean:     int32(v.TradeInClean),
-	//		TradeInAverage:   int32(v.TradeInAverage),
-	//		TradeInRough:     int32(v.TradeInRough),
-	//		RetailSource:     v.RetailSource,
-	//		Retail:           int32(v.Retail),
-	//		RetailClean:      int32(v.RetailClean),
-	//		RetailAverage:    int32(v.RetailAverage),
-	//		RetailRough:      int32(v.RetailRough),
-	//		OdometerUnit:     v.OdometerUnit,
-	//		Odometer:         int32(v.Odometer),
-	//		Currency:         v.Currency,
-	//		UserDisplayPrice: int32(v.UserDisplayPrice),
-	//	}
-	//}
-	//
-	//return &rpcValuations, nil
+// GetUserDeviceValuation latest valuation for the vehicle, for internal services authenticated by ServiceAuthInterceptor.
+// There is no privilege token so the stored vehicle location is used instead of the telemetry one.
+func (s *valuationsService) GetUserDeviceValuation(ctx context.Context, req *pb.DeviceValuationRequest) (*pb.DeviceValuation, error) {
+	if req.TokenId == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tokenId is required")
+	}
+	s.logger.Debug().Str("service", ServiceFromContext(ctx)).Uint64("token_id", req.TokenId).Msg("service valuation request")
+
+	valuations, err := s.userDeviceService.GetValuations(ctx, req.TokenId, "", "")
+	if err != nil {
+		s.logger.Err(err).Uint64("token_id", req.TokenId).Msg("failed to get valuations")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	rpcValuations := pb.DeviceValuation{
+		ValuationSets: make([]*pb.ValuationSet, len(valuations.ValuationSets)),
+	}
+
+	for i, v := range valuations.ValuationSets {
+		rpcValuations.ValuationSets[i] = &pb.ValuationSet{
+			Vendor:                  v.Vendor,
+			Updated:                 v.Updated,
+			Mileage:                 int32(v.Mileage),
+			ZipCode:                 v.ZipCode,
+			TradeInSource:           v.TradeInSource,
+			TradeIn:                 int32(v.TradeIn),
+			TradeInClean:            int32(v.TradeInClean),
+			TradeInAverage:          int32(v.TradeInAverage),
+			TradeInRough:            int32(v.TradeInRough),
+			RetailSource:            v.RetailSource,
+			Retail:                  int32(v.Retail),
+			RetailClean:             int32(v.RetailClean),
+			RetailAverage:           int32(v.RetailAverage),
+			RetailRough:             int32(v.RetailRough),
+			OdometerUnit:            v.OdometerUnit,
+			Odometer:                int32(v.Odometer),
+			Currency:                v.Currency,
+			UserDisplayPrice:        int32(v.UserDisplayPrice),
+			BlendingStrategy:        v.BlendingStrategy,
+			ConditionTier:           v.ConditionTier.String(),
+			BatteryAdjustment:       int32(v.BatteryAdjustment),
+			BatteryStateOfHealth:    float32(v.BatteryStateOfHealth),
+			MileageSource:           v.MileageSource.String(),
+			OdometerMeasurementType: string(v.OdometerMeasurementType),
+			TradeInSources:          sourcesToPb(v.TradeInSources),
+			RetailSources:           sourcesToPb(v.RetailSources),
+		}
+	}
+
+	return &rpcValuations, nil
 }
 
 func (s *valuationsService) GetUserDeviceOffer(ctx context.Context, req *pb.DeviceOfferRequest) (*pb.DeviceOffer, error) {
@@ -165,11 +166,13 @@ func (s *valuationsService) GetUserDeviceOffer(ctx context.Context, req *pb.Devi
 
 	for i, os := range offers.OfferSets {
 		rpcOffers.OfferSets[i] = &pb.OfferSet{
-			Source:  os.Source,
-			Updated: os.Updated,
-			Mileage: int32(os.Mileage),
-			ZipCode: os.ZipCode,
-			Offers:  make([]*pb.Offer, len(os.Offers)),
+			Source:    os.Source,
+			Updated:   os.Updated,
+			Mileage:   int32(os.Mileage),
+			ZipCode:   os.ZipCode,
+			Offers:    make([]*pb.Offer, len(os.Offers)),
+			ExpiresAt: os.ExpiresAt,
+			Expired:   os.Expired,
 		}
 
 		for j, o := range os.Offers {
@@ -186,3 +189,46 @@ func (s *valuationsService) GetUserDeviceOffer(ctx context.Context, req *pb.Devi
 
 	return &rpcOffers, nil
 }
+
+// GetOwnerValuations latest valuation of every vehicle owned by the address, for internal services authenticated by
+// ServiceAuthInterceptor.
+func (s *valuationsService) GetOwnerValuations(ctx context.Context, req *pb.OwnerValuationsRequest) (*pb.OwnerValuations, error) {
+	if !common.IsHexAddress(req.Owner) {
+		return nil, status.Error(codes.InvalidArgument, "invalid owner address")
+	}
+	portfolio, err := s.portfolio.GetOwnerPortfolio(ctx, common.HexToAddress(req.Owner).Hex(), req.Currency)
+	if err != nil {
+		if errors.Is(err, services.ErrNoFxRate) {
+			return nil, status.Error(codes.InvalidArgument, "no exchange rate for currency "+req.Currency)
+		}
+		s.logger.Err(err).Str("owner", req.Owner).Msg("failed to get owner valuations")
+		return nil, status.Error(codes.Internal, "Internal error.")
+	}
+
+	resp := &pb.OwnerValuations{
+		Owner:                  portfolio.Owner,
+		Currency:               portfolio.Currency,
+		Vehicles:               make([]*pb.OwnerVehicleValuation, len(portfolio.Vehicles)),
+		TotalValue:             int64(portfolio.TotalValue),
+		ValuedCount:            int32(portfolio.ValuedCount),
+		UnvaluedCount:          int32(portfolio.UnvaluedCount),
+		EstimatedUnvaluedValue: int64(portfolio.EstimatedUnvaluedValue),
+		EstimatedTotalValue:    int64(portfolio.EstimatedTotalValue),
+	}
+	for i, v := range portfolio.Vehicles {
+		resp.Vehicles[i] = &pb.OwnerVehicleValuation{
+			TokenId:      v.TokenID,
+			DefinitionId: v.DefinitionID,
+			Make:         v.Make,
+			Model:        v.Model,
+			Year:         int32(v.Year),
+			Valued:       v.Valued,
+			Value:        int32(v.Value),
+			Vendor:       v.Vendor,
+			Updated:      v.Updated,
+			Estimated:    v.Estimated,
+		}
+	}
+
+	return resp, nil
+}
@@ -26,8 +26,16 @@ type ValuationResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Total            float32 `protobuf:"fixed32,1,opt,name=total,proto3" json:"total,omitempty"`
-	GrowthPercentage float32 `protobuf:"fixed32,2,opt,name=growthPercentage,proto3" json:"growthPercentage,omitempty"`
+	// USD
+	Total float32 `protobuf:"fixed32,1,opt,name=total,proto3" json:"total,omitempty"`
+	// week over week growth, 0 if there is no snapshot from a week ago
+	GrowthPercentage     float32               `protobuf:"fixed32,2,opt,name=growthPercentage,proto3" json:"growthPercentage,omitempty"`
+	SnapshotDate         string                `protobuf:"bytes,3,opt,name=snapshotDate,proto3" json:"snapshotDate,omitempty"`
+	VehicleCount         int32                 `protobuf:"varint,4,opt,name=vehicleCount,proto3" json:"vehicleCount,omitempty"`
+	WeekOverWeekGrowth   *float32              `protobuf:"fixed32,5,opt,name=weekOverWeekGrowth,proto3,oneof" json:"weekOverWeekGrowth,omitempty"`
+	MonthOverMonthGrowth *float32              `protobuf:"fixed32,6,opt,name=monthOverMonthGrowth,proto3,oneof" json:"monthOverMonthGrowth,omitempty"`
+	ByDefinition         []*ValuationBreakdown `protobuf:"bytes,7,rep,name=byDefinition,proto3" json:"byDefinition,omitempty"`
+	ByCountry            []*ValuationBreakdown `protobuf:"bytes,8,rep,name=byCountry,proto3" json:"byCountry,omitempty"`
 }
 
 func (x *ValuationResponse) Reset() {
@@ -76,6 +84,136 @@ func (x *ValuationResponse) GetGrowthPercentage() float32 {
 	return 0
 }
 
+func (x *ValuationResponse) GetSnapshotDate() string {
+	if x != nil {
+		return x.SnapshotDate
+	}
+	return ""
+}
+
+func (x *ValuationResponse) GetVehicleCount() int32 {
+	if x != nil {
+		return x.VehicleCount
+	}
+	return 0
+}
+
+func (x *ValuationResponse) GetWeekOverWeekGrowth() float32 {
+	if x != nil && x.WeekOverWeekGrowth != nil {
+		return *x.WeekOverWeekGrowth
+	}
+	return 0
+}
+
+func (x *ValuationResponse) GetMonthOverMonthGrowth() float32 {
+	if x != nil && x.MonthOverMonthGrowth != nil {
+		return *x.MonthOverMonthGrowth
+	}
+	return 0
+}
+
+func (x *ValuationResponse) GetByDefinition() []*ValuationBreakdown {
+	if x != nil {
+		return x.ByDefinition
+	}
+	return nil
+}
+
+func (x *ValuationResponse) GetByCountry() []*ValuationBreakdown {
+	if x != nil {
+		return x.ByCountry
+	}
+	return nil
+}
+
+type ValuationBreakdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// definition id or alpha-3 country code, empty if unknown
+	Key          string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Make         string  `protobuf:"bytes,2,opt,name=make,proto3" json:"make,omitempty"`
+	Model        string  `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Year         int32   `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`
+	Total        float32 `protobuf:"fixed32,5,opt,name=total,proto3" json:"total,omitempty"`
+	VehicleCount int32   `protobuf:"varint,6,opt,name=vehicleCount,proto3" json:"vehicleCount,omitempty"`
+}
+
+func (x *ValuationBreakdown) Reset() {
+	*x = ValuationBreakdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValuationBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuationBreakdown) ProtoMessage() {}
+
+func (x *ValuationBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuationBreakdown.ProtoReflect.Descriptor instead.
+func (*ValuationBreakdown) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValuationBreakdown) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ValuationBreakdown) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *ValuationBreakdown) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *ValuationBreakdown) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *ValuationBreakdown) GetTotal() float32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ValuationBreakdown) GetVehicleCount() int32 {
+	if x != nil {
+		return x.VehicleCount
+	}
+	return 0
+}
+
 type DeviceValuationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -89,7 +227,7 @@ type DeviceValuationRequest struct {
 func (x *DeviceValuationRequest) Reset() {
 	*x = DeviceValuationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_grpc_valuations_proto_msgTypes[1]
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -102,7 +240,7 @@ func (x *DeviceValuationRequest) String() string {
 func (*DeviceValuationRequest) ProtoMessage() {}
 
 func (x *DeviceValuationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_valuations_proto_msgTypes[1]
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,7 +253,7 @@ func (x *DeviceValuationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeviceValuationRequest.ProtoReflect.Descriptor instead.
 func (*DeviceValuationRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{1}
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{2}
 }
 
 // Deprecated: Marked as deprecated in pkg/grpc/valuations.proto.
@@ -146,7 +284,7 @@ type DeviceOfferRequest struct {
 func (x *DeviceOfferRequest) Reset() {
 	*x = DeviceOfferRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_grpc_valuations_proto_msgTypes[2]
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -159,7 +297,7 @@ func (x *DeviceOfferRequest) String() string {
 func (*DeviceOfferRequest) ProtoMessage() {}
 
 func (x *DeviceOfferRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_valuations_proto_msgTypes[2]
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -172,7 +310,7 @@ func (x *DeviceOfferRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeviceOfferRequest.ProtoReflect.Descriptor instead.
 func (*DeviceOfferRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{2}
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{3}
 }
 
 // Deprecated: Marked as deprecated in pkg/grpc/valuations.proto.
@@ -190,6 +328,286 @@ func (x *DeviceOfferRequest) GetTokenId() uint64 {
 	return 0
 }
 
+type OwnerValuationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ethereum address
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	// ISO 4217 currency to convert values to, default USD
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+}
+
+func (x *OwnerValuationsRequest) Reset() {
+	*x = OwnerValuationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OwnerValuationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnerValuationsRequest) ProtoMessage() {}
+
+func (x *OwnerValuationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnerValuationsRequest.ProtoReflect.Descriptor instead.
+func (*OwnerValuationsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OwnerValuationsRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *OwnerValuationsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type OwnerValuations struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Owner                  string                   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Currency               string                   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Vehicles               []*OwnerVehicleValuation `protobuf:"bytes,3,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	TotalValue             int64                    `protobuf:"varint,4,opt,name=totalValue,proto3" json:"totalValue,omitempty"`
+	ValuedCount            int32                    `protobuf:"varint,5,opt,name=valuedCount,proto3" json:"valuedCount,omitempty"`
+	UnvaluedCount          int32                    `protobuf:"varint,6,opt,name=unvaluedCount,proto3" json:"unvaluedCount,omitempty"`
+	EstimatedUnvaluedValue int64                    `protobuf:"varint,7,opt,name=estimatedUnvaluedValue,proto3" json:"estimatedUnvaluedValue,omitempty"`
+	EstimatedTotalValue    int64                    `protobuf:"varint,8,opt,name=estimatedTotalValue,proto3" json:"estimatedTotalValue,omitempty"`
+}
+
+func (x *OwnerValuations) Reset() {
+	*x = OwnerValuations{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OwnerValuations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnerValuations) ProtoMessage() {}
+
+func (x *OwnerValuations) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnerValuations.ProtoReflect.Descriptor instead.
+func (*OwnerValuations) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OwnerValuations) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *OwnerValuations) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OwnerValuations) GetVehicles() []*OwnerVehicleValuation {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *OwnerValuations) GetTotalValue() int64 {
+	if x != nil {
+		return x.TotalValue
+	}
+	return 0
+}
+
+func (x *OwnerValuations) GetValuedCount() int32 {
+	if x != nil {
+		return x.ValuedCount
+	}
+	return 0
+}
+
+func (x *OwnerValuations) GetUnvaluedCount() int32 {
+	if x != nil {
+		return x.UnvaluedCount
+	}
+	return 0
+}
+
+func (x *OwnerValuations) GetEstimatedUnvaluedValue() int64 {
+	if x != nil {
+		return x.EstimatedUnvaluedValue
+	}
+	return 0
+}
+
+func (x *OwnerValuations) GetEstimatedTotalValue() int64 {
+	if x != nil {
+		return x.EstimatedTotalValue
+	}
+	return 0
+}
+
+type OwnerVehicleValuation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenId      uint64 `protobuf:"varint,1,opt,name=tokenId,proto3" json:"tokenId,omitempty"`
+	DefinitionId string `protobuf:"bytes,2,opt,name=definitionId,proto3" json:"definitionId,omitempty"`
+	Make         string `protobuf:"bytes,3,opt,name=make,proto3" json:"make,omitempty"`
+	Model        string `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Year         int32  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
+	Valued       bool   `protobuf:"varint,6,opt,name=valued,proto3" json:"valued,omitempty"`
+	Value        int32  `protobuf:"varint,7,opt,name=value,proto3" json:"value,omitempty"`
+	Vendor       string `protobuf:"bytes,8,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	Updated      string `protobuf:"bytes,9,opt,name=updated,proto3" json:"updated,omitempty"`
+	// value is an estimate from the owner's valued vehicles
+	Estimated bool `protobuf:"varint,10,opt,name=estimated,proto3" json:"estimated,omitempty"`
+}
+
+func (x *OwnerVehicleValuation) Reset() {
+	*x = OwnerVehicleValuation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OwnerVehicleValuation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnerVehicleValuation) ProtoMessage() {}
+
+func (x *OwnerVehicleValuation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnerVehicleValuation.ProtoReflect.Descriptor instead.
+func (*OwnerVehicleValuation) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OwnerVehicleValuation) GetTokenId() uint64 {
+	if x != nil {
+		return x.TokenId
+	}
+	return 0
+}
+
+func (x *OwnerVehicleValuation) GetDefinitionId() string {
+	if x != nil {
+		return x.DefinitionId
+	}
+	return ""
+}
+
+func (x *OwnerVehicleValuation) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *OwnerVehicleValuation) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *OwnerVehicleValuation) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *OwnerVehicleValuation) GetValued() bool {
+	if x != nil {
+		return x.Valued
+	}
+	return false
+}
+
+func (x *OwnerVehicleValuation) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *OwnerVehicleValuation) GetVendor() string {
+	if x != nil {
+		return x.Vendor
+	}
+	return ""
+}
+
+func (x *OwnerVehicleValuation) GetUpdated() string {
+	if x != nil {
+		return x.Updated
+	}
+	return ""
+}
+
+func (x *OwnerVehicleValuation) GetEstimated() bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return false
+}
+
 type DeviceValuation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -201,7 +619,7 @@ type DeviceValuation struct {
 func (x *DeviceValuation) Reset() {
 	*x = DeviceValuation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_grpc_valuations_proto_msgTypes[3]
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -214,7 +632,7 @@ func (x *DeviceValuation) String() string {
 func (*DeviceValuation) ProtoMessage() {}
 
 func (x *DeviceValuation) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_valuations_proto_msgTypes[3]
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,7 +645,7 @@ func (x *DeviceValuation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeviceValuation.ProtoReflect.Descriptor instead.
 func (*DeviceValuation) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{3}
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeviceValuation) GetValuationSets() []*ValuationSet {
@@ -248,7 +666,7 @@ type DeviceOffer struct {
 func (x *DeviceOffer) Reset() {
 	*x = DeviceOffer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_grpc_valuations_proto_msgTypes[4]
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -261,7 +679,7 @@ func (x *DeviceOffer) String() string {
 func (*DeviceOffer) ProtoMessage() {}
 
 func (x *DeviceOffer) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_valuations_proto_msgTypes[4]
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -274,7 +692,7 @@ func (x *DeviceOffer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeviceOffer.ProtoReflect.Descriptor instead.
 func (*DeviceOffer) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{4}
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeviceOffer) GetOfferSets() []*OfferSet {
@@ -307,12 +725,27 @@ type ValuationSet struct {
 	Odometer         int32  `protobuf:"varint,16,opt,name=odometer,proto3" json:"odometer,omitempty"`
 	UserDisplayPrice int32  `protobuf:"varint,17,opt,name=userDisplayPrice,proto3" json:"userDisplayPrice,omitempty"`
 	Currency         string `protobuf:"bytes,18,opt,name=currency,proto3" json:"currency,omitempty"`
+	// estimated, real or market
+	OdometerMeasurementType string `protobuf:"bytes,19,opt,name=odometerMeasurementType,proto3" json:"odometerMeasurementType,omitempty"`
+	// book values drivly blended into tradeIn and retail
+	TradeInSources []*ValuationSource `protobuf:"bytes,20,rep,name=tradeInSources,proto3" json:"tradeInSources,omitempty"`
+	RetailSources  []*ValuationSource `protobuf:"bytes,21,rep,name=retailSources,proto3" json:"retailSources,omitempty"`
+	// how userDisplayPrice was blended, eg. vendor, mean or median
+	BlendingStrategy string `protobuf:"bytes,22,opt,name=blendingStrategy,proto3" json:"blendingStrategy,omitempty"`
+	// clean, average or rough, scored from the vehicle's diagnostic signals
+	ConditionTier string `protobuf:"bytes,23,opt,name=conditionTier,proto3" json:"conditionTier,omitempty"`
+	// amount userDisplayPrice was adjusted by for the EV battery health
+	BatteryAdjustment int32 `protobuf:"varint,24,opt,name=batteryAdjustment,proto3" json:"batteryAdjustment,omitempty"`
+	// percent of the original EV battery capacity, 0 when unknown
+	BatteryStateOfHealth float32 `protobuf:"fixed32,25,opt,name=batteryStateOfHealth,proto3" json:"batteryStateOfHealth,omitempty"`
+	// telemetry, extrapolated or country_average, empty for valuations from before it was recorded
+	MileageSource string `protobuf:"bytes,26,opt,name=mileageSource,proto3" json:"mileageSource,omitempty"`
 }
 
 func (x *ValuationSet) Reset() {
 	*x = ValuationSet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_grpc_valuations_proto_msgTypes[5]
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -325,7 +758,7 @@ func (x *ValuationSet) String() string {
 func (*ValuationSet) ProtoMessage() {}
 
 func (x *ValuationSet) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_valuations_proto_msgTypes[5]
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,7 +771,7 @@ func (x *ValuationSet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValuationSet.ProtoReflect.Descriptor instead.
 func (*ValuationSet) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{5}
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ValuationSet) GetVendor() string {
@@ -467,22 +900,161 @@ func (x *ValuationSet) GetCurrency() string {
 	return ""
 }
 
+func (x *ValuationSet) GetOdometerMeasurementType() string {
+	if x != nil {
+		return x.OdometerMeasurementType
+	}
+	return ""
+}
+
+func (x *ValuationSet) GetTradeInSources() []*ValuationSource {
+	if x != nil {
+		return x.TradeInSources
+	}
+	return nil
+}
+
+func (x *ValuationSet) GetRetailSources() []*ValuationSource {
+	if x != nil {
+		return x.RetailSources
+	}
+	return nil
+}
+
+func (x *ValuationSet) GetBlendingStrategy() string {
+	if x != nil {
+		return x.BlendingStrategy
+	}
+	return ""
+}
+
+func (x *ValuationSet) GetConditionTier() string {
+	if x != nil {
+		return x.ConditionTier
+	}
+	return ""
+}
+
+func (x *ValuationSet) GetBatteryAdjustment() int32 {
+	if x != nil {
+		return x.BatteryAdjustment
+	}
+	return 0
+}
+
+func (x *ValuationSet) GetBatteryStateOfHealth() float32 {
+	if x != nil {
+		return x.BatteryStateOfHealth
+	}
+	return 0
+}
+
+func (x *ValuationSet) GetMileageSource() string {
+	if x != nil {
+		return x.MileageSource
+	}
+	return ""
+}
+
+type ValuationSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// eg. blackbook, kbb, edmunds, nada or cargurus
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Value  int32  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Clean  int32  `protobuf:"varint,3,opt,name=clean,proto3" json:"clean,omitempty"`
+	Rough  int32  `protobuf:"varint,4,opt,name=rough,proto3" json:"rough,omitempty"`
+	// whether the value was used in the blended price
+	Included bool `protobuf:"varint,5,opt,name=included,proto3" json:"included,omitempty"`
+}
+
+func (x *ValuationSource) Reset() {
+	*x = ValuationSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValuationSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValuationSource) ProtoMessage() {}
+
+func (x *ValuationSource) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValuationSource.ProtoReflect.Descriptor instead.
+func (*ValuationSource) Descriptor() ([]byte, []int) {
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValuationSource) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ValuationSource) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ValuationSource) GetClean() int32 {
+	if x != nil {
+		return x.Clean
+	}
+	return 0
+}
+
+func (x *ValuationSource) GetRough() int32 {
+	if x != nil {
+		return x.Rough
+	}
+	return 0
+}
+
+func (x *ValuationSource) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
 type OfferSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Source  string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Updated string   `protobuf:"bytes,2,opt,name=updated,proto3" json:"updated,omitempty"`
-	Mileage int32    `protobuf:"varint,3,opt,name=mileage,proto3" json:"mileage,omitempty"`
-	ZipCode string   `protobuf:"bytes,4,opt,name=zipCode,proto3" json:"zipCode,omitempty"`
-	Offers  []*Offer `protobuf:"bytes,5,rep,name=offers,proto3" json:"offers,omitempty"`
+	Source    string   `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Updated   string   `protobuf:"bytes,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Mileage   int32    `protobuf:"varint,3,opt,name=mileage,proto3" json:"mileage,omitempty"`
+	ZipCode   string   `protobuf:"bytes,4,opt,name=zipCode,proto3" json:"zipCode,omitempty"`
+	Offers    []*Offer `protobuf:"bytes,5,rep,name=offers,proto3" json:"offers,omitempty"`
+	ExpiresAt string   `protobuf:"bytes,6,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	Expired   bool     `protobuf:"varint,7,opt,name=expired,proto3" json:"expired,omitempty"`
 }
 
 func (x *OfferSet) Reset() {
 	*x = OfferSet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_grpc_valuations_proto_msgTypes[6]
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -495,7 +1067,7 @@ func (x *OfferSet) String() string {
 func (*OfferSet) ProtoMessage() {}
 
 func (x *OfferSet) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_valuations_proto_msgTypes[6]
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,7 +1080,7 @@ func (x *OfferSet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OfferSet.ProtoReflect.Descriptor instead.
 func (*OfferSet) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{6}
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *OfferSet) GetSource() string {
@@ -546,6 +1118,20 @@ func (x *OfferSet) GetOffers() []*Offer {
 	return nil
 }
 
+func (x *OfferSet) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *OfferSet) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
 type Offer struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -562,7 +1148,7 @@ type Offer struct {
 func (x *Offer) Reset() {
 	*x = Offer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_grpc_valuations_proto_msgTypes[7]
+		mi := &file_pkg_grpc_valuations_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -575,7 +1161,7 @@ func (x *Offer) String() string {
 func (*Offer) ProtoMessage() {}
 
 func (x *Offer) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_grpc_valuations_proto_msgTypes[7]
+	mi := &file_pkg_grpc_valuations_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,7 +1174,7 @@ func (x *Offer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Offer.ProtoReflect.Descriptor instead.
 func (*Offer) Descriptor() ([]byte, []int) {
-	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{7}
+	return file_pkg_grpc_valuations_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *Offer) GetVendor() string {
@@ -640,115 +1226,235 @@ var file_pkg_grpc_valuations_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x76, 0x61, 0x6c,
 	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x55, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
-	0x2a, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10, 0x67, 0x72, 0x6f, 0x77, 0x74,
-	0x68, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x22, 0x5a, 0x0a, 0x16, 0x44,
-	0x65, 0x76, 0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52,
-	0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x22, 0x56, 0x0a, 0x12, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a,
-	0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x22,
-	0x51, 0x0a, 0x0f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
-	0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x61, 0x6c, 0x75,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbd, 0x03, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x12, 0x2a, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x10, 0x67, 0x72, 0x6f, 0x77,
+	0x74, 0x68, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x44, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x12, 0x77, 0x65, 0x65, 0x6b, 0x4f, 0x76, 0x65, 0x72,
+	0x57, 0x65, 0x65, 0x6b, 0x47, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02,
+	0x48, 0x00, 0x52, 0x12, 0x77, 0x65, 0x65, 0x6b, 0x4f, 0x76, 0x65, 0x72, 0x57, 0x65, 0x65, 0x6b,
+	0x47, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x88, 0x01, 0x01, 0x12, 0x37, 0x0a, 0x14, 0x6d, 0x6f, 0x6e,
+	0x74, 0x68, 0x4f, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x77, 0x74,
+	0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x48, 0x01, 0x52, 0x14, 0x6d, 0x6f, 0x6e, 0x74, 0x68,
+	0x4f, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x88,
+	0x01, 0x01, 0x12, 0x42, 0x0a, 0x0c, 0x62, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42,
+	0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x0c, 0x62, 0x79, 0x44, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x09, 0x62, 0x79, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x76, 0x61, 0x6c, 0x75,
 	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x53, 0x65, 0x74, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65,
-	0x74, 0x73, 0x22, 0x41, 0x0a, 0x0b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65,
-	0x72, 0x12, 0x32, 0x0a, 0x09, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x09, 0x6f, 0x66, 0x66, 0x65,
-	0x72, 0x53, 0x65, 0x74, 0x73, 0x22, 0xd2, 0x04, 0x0a, 0x0c, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x6c, 0x65,
-	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x69, 0x6c, 0x65, 0x61,
-	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x7a, 0x69, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x7a, 0x69, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d,
-	0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x12, 0x22, 0x0a, 0x0c,
-	0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x43, 0x6c, 0x65, 0x61, 0x6e,
-	0x12, 0x26, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x41, 0x76, 0x65, 0x72, 0x61,
-	0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49,
-	0x6e, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x64,
-	0x65, 0x49, 0x6e, 0x52, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
-	0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x52, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x22, 0x0a, 0x0c,
-	0x52, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x52, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72,
-	0x65, 0x74, 0x61, 0x69, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65,
-	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x6f, 0x75, 0x67, 0x68, 0x18,
-	0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x6f, 0x75,
-	0x67, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x64, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x55, 0x6e,
-	0x69, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x64, 0x6f, 0x6d, 0x65, 0x74,
-	0x65, 0x72, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x64, 0x6f, 0x6d, 0x65, 0x74,
-	0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x64, 0x6f, 0x6d, 0x65, 0x74,
-	0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61,
-	0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x75, 0x73,
-	0x65, 0x72, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x9b, 0x01, 0x0a, 0x08, 0x4f,
-	0x66, 0x66, 0x65, 0x72, 0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x09, 0x62, 0x79, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x79, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x4f, 0x76, 0x65,
+	0x72, 0x57, 0x65, 0x65, 0x6b, 0x47, 0x72, 0x6f, 0x77, 0x74, 0x68, 0x42, 0x17, 0x0a, 0x15, 0x5f,
+	0x6d, 0x6f, 0x6e, 0x74, 0x68, 0x4f, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x6e, 0x74, 0x68, 0x47, 0x72,
+	0x6f, 0x77, 0x74, 0x68, 0x22, 0x9e, 0x01, 0x0a, 0x12, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x6d, 0x61, 0x6b, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x6b,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x12, 0x22, 0x0a, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5a, 0x0a, 0x16, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x56,
+	0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x26, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49,
+	0x64, 0x22, 0x56, 0x0a, 0x12, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18,
+	0x01, 0x52, 0x0c, 0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x22, 0x4a, 0x0a, 0x16, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0xd4, 0x02, 0x0a, 0x0f, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x56,
+	0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x3d, 0x0a, 0x08, 0x76,
+	0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x08, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d,
+	0x75, 0x6e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x75, 0x6e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x36, 0x0a, 0x16, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x55,
+	0x6e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x16, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x55, 0x6e, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x65, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74,
+	0x65, 0x64, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x91, 0x02, 0x0a,
+	0x15, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x56, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x61, 0x6b, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6d, 0x61, 0x6b, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x12,
+	0x0a, 0x04, 0x79, 0x65, 0x61, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65,
+	0x61, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64,
+	0x22, 0x51, 0x0a, 0x0f, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x65, 0x74, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x65, 0x74, 0x73, 0x22, 0x41, 0x0a, 0x0b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x12, 0x32, 0x0a, 0x09, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x09, 0x6f, 0x66, 0x66,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x22, 0xee, 0x07, 0x0a, 0x0c, 0x56, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12,
 	0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x6c,
 	0x65, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x69, 0x6c, 0x65,
 	0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x7a, 0x69, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x7a, 0x69, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a,
-	0x06, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72,
-	0x52, 0x06, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x05, 0x4f, 0x66, 0x66,
-	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72,
-	0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65,
-	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
-	0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x64,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x24,
-	0x0a, 0x0d, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65,
-	0x61, 0x73, 0x6f, 0x6e, 0x32, 0xdc, 0x02, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x10, 0x47, 0x65,
-	0x74, 0x41, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x7a, 0x69, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x12, 0x22, 0x0a,
+	0x0c, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x43, 0x6c, 0x65, 0x61,
+	0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x41, 0x76, 0x65, 0x72,
+	0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x64, 0x65,
+	0x49, 0x6e, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61,
+	0x64, 0x65, 0x49, 0x6e, 0x52, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e, 0x52, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x22, 0x0a,
+	0x0c, 0x52, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x52, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x72,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x6f, 0x75, 0x67, 0x68,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x6f,
+	0x75, 0x67, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x6f, 0x64, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x55,
+	0x6e, 0x69, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x64, 0x6f, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x64, 0x6f, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x64, 0x6f, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x44, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x75,
+	0x73, 0x65, 0x72, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x38, 0x0a, 0x17, 0x6f,
+	0x64, 0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x6f, 0x64,
+	0x6f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x43, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x64, 0x65, 0x49, 0x6e,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x64,
+	0x65, 0x49, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x0d, 0x72, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0d,
+	0x72, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2a, 0x0a,
+	0x10, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x79, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x6c, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x65, 0x72, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x65, 0x72, 0x12,
+	0x2c, 0x0a, 0x11, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x62, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x41, 0x64, 0x6a, 0x75, 0x73, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a,
+	0x14, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x19, 0x20, 0x01, 0x28, 0x02, 0x52, 0x14, 0x62, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x65, 0x4f, 0x66, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x69, 0x6c, 0x65, 0x61, 0x67, 0x65, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x69, 0x6c, 0x65, 0x61, 0x67,
+	0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x75,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x65,
+	0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x67, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x72, 0x6f, 0x75, 0x67, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x64, 0x22, 0xd3, 0x01, 0x0a, 0x08, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x53, 0x65, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x6c, 0x65, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x6d, 0x69, 0x6c, 0x65, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x7a, 0x69,
+	0x70, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x7a, 0x69, 0x70,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x2e, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x22, 0x99, 0x01, 0x0a, 0x05, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x63, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x32, 0xb3, 0x03, 0x0a, 0x11, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x41, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22,
+	0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
 	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x22, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x4d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63,
-	0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12,
-	0x52, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76,
-	0x69, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x44, 0x49, 0x4d, 0x4f, 0x2d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x76,
-	0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b,
-	0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4d, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x52,
+	0x0a, 0x19, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x55, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x56, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76,
+	0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x56,
+	0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x44, 0x49, 0x4d, 0x4f, 0x2d, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2d,
+	0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -763,35 +1469,47 @@ func file_pkg_grpc_valuations_proto_rawDescGZIP() []byte {
 	return file_pkg_grpc_valuations_proto_rawDescData
 }
 
-var file_pkg_grpc_valuations_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_pkg_grpc_valuations_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_pkg_grpc_valuations_proto_goTypes = []interface{}{
 	(*ValuationResponse)(nil),      // 0: valuations.ValuationResponse
-	(*DeviceValuationRequest)(nil), // 1: valuations.DeviceValuationRequest
-	(*DeviceOfferRequest)(nil),     // 2: valuations.DeviceOfferRequest
-	(*DeviceValuation)(nil),        // 3: valuations.DeviceValuation
-	(*DeviceOffer)(nil),            // 4: valuations.DeviceOffer
-	(*ValuationSet)(nil),           // 5: valuations.ValuationSet
-	(*OfferSet)(nil),               // 6: valuations.OfferSet
-	(*Offer)(nil),                  // 7: valuations.Offer
-	(*emptypb.Empty)(nil),          // 8: google.protobuf.Empty
+	(*ValuationBreakdown)(nil),     // 1: valuations.ValuationBreakdown
+	(*DeviceValuationRequest)(nil), // 2: valuations.DeviceValuationRequest
+	(*DeviceOfferRequest)(nil),     // 3: valuations.DeviceOfferRequest
+	(*OwnerValuationsRequest)(nil), // 4: valuations.OwnerValuationsRequest
+	(*OwnerValuations)(nil),        // 5: valuations.OwnerValuations
+	(*OwnerVehicleValuation)(nil),  // 6: valuations.OwnerVehicleValuation
+	(*DeviceValuation)(nil),        // 7: valuations.DeviceValuation
+	(*DeviceOffer)(nil),            // 8: valuations.DeviceOffer
+	(*ValuationSet)(nil),           // 9: valuations.ValuationSet
+	(*ValuationSource)(nil),        // 10: valuations.ValuationSource
+	(*OfferSet)(nil),               // 11: valuations.OfferSet
+	(*Offer)(nil),                  // 12: valuations.Offer
+	(*emptypb.Empty)(nil),          // 13: google.protobuf.Empty
 }
 var file_pkg_grpc_valuations_proto_depIdxs = []int32{
-	5, // 0: valuations.DeviceValuation.valuationSets:type_name -> valuations.ValuationSet
-	6, // 1: valuations.DeviceOffer.offerSets:type_name -> valuations.OfferSet
-	7, // 2: valuations.OfferSet.offers:type_name -> valuations.Offer
-	8, // 3: valuations.ValuationsService.GetAllValuations:input_type -> google.protobuf.Empty
-	1, // 4: valuations.ValuationsService.GetUserDeviceValuation:input_type -> valuations.DeviceValuationRequest
-	2, // 5: valuations.ValuationsService.GetUserDeviceOffer:input_type -> valuations.DeviceOfferRequest
-	8, // 6: valuations.ValuationsService.GetAllUserDeviceValuation:input_type -> google.protobuf.Empty
-	0, // 7: valuations.ValuationsService.GetAllValuations:output_type -> valuations.ValuationResponse
-	3, // 8: valuations.ValuationsService.GetUserDeviceValuation:output_type -> valuations.DeviceValuation
-	4, // 9: valuations.ValuationsService.GetUserDeviceOffer:output_type -> valuations.DeviceOffer
-	0, // 10: valuations.ValuationsService.GetAllUserDeviceValuation:output_type -> valuations.ValuationResponse
-	7, // [7:11] is the sub-list for method output_type
-	3, // [3:7] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	1,  // 0: valuations.ValuationResponse.byDefinition:type_name -> valuations.ValuationBreakdown
+	1,  // 1: valuations.ValuationResponse.byCountry:type_name -> valuations.ValuationBreakdown
+	6,  // 2: valuations.OwnerValuations.vehicles:type_name -> valuations.OwnerVehicleValuation
+	9,  // 3: valuations.DeviceValuation.valuationSets:type_name -> valuations.ValuationSet
+	11, // 4: valuations.DeviceOffer.offerSets:type_name -> valuations.OfferSet
+	10, // 5: valuations.ValuationSet.tradeInSources:type_name -> valuations.ValuationSource
+	10, // 6: valuations.ValuationSet.retailSources:type_name -> valuations.ValuationSource
+	12, // 7: valuations.OfferSet.offers:type_name -> valuations.Offer
+	13, // 8: valuations.ValuationsService.GetAllValuations:input_type -> google.protobuf.Empty
+	2,  // 9: valuations.ValuationsService.GetUserDeviceValuation:input_type -> valuations.DeviceValuationRequest
+	3,  // 10: valuations.ValuationsService.GetUserDeviceOffer:input_type -> valuations.DeviceOfferRequest
+	13, // 11: valuations.ValuationsService.GetAllUserDeviceValuation:input_type -> google.protobuf.Empty
+	4,  // 12: valuations.ValuationsService.GetOwnerValuations:input_type -> valuations.OwnerValuationsRequest
+	0,  // 13: valuations.ValuationsService.GetAllValuations:output_type -> valuations.ValuationResponse
+	7,  // 14: valuations.ValuationsService.GetUserDeviceValuation:output_type -> valuations.DeviceValuation
+	8,  // 15: valuations.ValuationsService.GetUserDeviceOffer:output_type -> valuations.DeviceOffer
+	0,  // 16: valuations.ValuationsService.GetAllUserDeviceValuation:output_type -> valuations.ValuationResponse
+	5,  // 17: valuations.ValuationsService.GetOwnerValuations:output_type -> valuations.OwnerValuations
+	13, // [13:18] is the sub-list for method output_type
+	8,  // [8:13] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_pkg_grpc_valuations_proto_init() }
@@ -813,7 +1531,7 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 		file_pkg_grpc_valuations_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeviceValuationRequest); i {
+			switch v := v.(*ValuationBreakdown); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -825,7 +1543,7 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 		file_pkg_grpc_valuations_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeviceOfferRequest); i {
+			switch v := v.(*DeviceValuationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -837,7 +1555,7 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 		file_pkg_grpc_valuations_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeviceValuation); i {
+			switch v := v.(*DeviceOfferRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -849,7 +1567,7 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 		file_pkg_grpc_valuations_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeviceOffer); i {
+			switch v := v.(*OwnerValuationsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -861,7 +1579,7 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 		file_pkg_grpc_valuations_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValuationSet); i {
+			switch v := v.(*OwnerValuations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -873,7 +1591,7 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 		file_pkg_grpc_valuations_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OfferSet); i {
+			switch v := v.(*OwnerVehicleValuation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -885,6 +1603,66 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 		file_pkg_grpc_valuations_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceValuation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_valuations_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceOffer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_valuations_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValuationSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_valuations_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValuationSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_valuations_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_grpc_valuations_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Offer); i {
 			case 0:
 				return &v.state
@@ -897,13 +1675,14 @@ func file_pkg_grpc_valuations_proto_init() {
 			}
 		}
 	}
+	file_pkg_grpc_valuations_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_grpc_valuations_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
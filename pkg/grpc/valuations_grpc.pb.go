@@ -24,16 +24,21 @@ const (
 	ValuationsService_GetUserDeviceValuation_FullMethodName    = "/valuations.ValuationsService/GetUserDeviceValuation"
 	ValuationsService_GetUserDeviceOffer_FullMethodName        = "/valuations.ValuationsService/GetUserDeviceOffer"
 	ValuationsService_GetAllUserDeviceValuation_FullMethodName = "/valuations.ValuationsService/GetAllUserDeviceValuation"
+	ValuationsService_GetOwnerValuations_FullMethodName        = "/valuations.ValuationsService/GetOwnerValuations"
 )
 
 // ValuationsServiceClient is the client API for ValuationsService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ValuationsServiceClient interface {
+	// network value from the daily snapshot with growth and breakdowns
 	GetAllValuations(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ValuationResponse, error)
 	GetUserDeviceValuation(ctx context.Context, in *DeviceValuationRequest, opts ...grpc.CallOption) (*DeviceValuation, error)
 	GetUserDeviceOffer(ctx context.Context, in *DeviceOfferRequest, opts ...grpc.CallOption) (*DeviceOffer, error)
+	// same as GetAllValuations
 	GetAllUserDeviceValuation(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ValuationResponse, error)
+	// latest valuation of every vehicle owned by the address, with totals
+	GetOwnerValuations(ctx context.Context, in *OwnerValuationsRequest, opts ...grpc.CallOption) (*OwnerValuations, error)
 }
 
 type valuationsServiceClient struct {
@@ -80,14 +85,27 @@ func (c *valuationsServiceClient) GetAllUserDeviceValuation(ctx context.Context,
 	return out, nil
 }
 
+func (c *valuationsServiceClient) GetOwnerValuations(ctx context.Context, in *OwnerValuationsRequest, opts ...grpc.CallOption) (*OwnerValuations, error) {
+	out := new(OwnerValuations)
+	err := c.cc.Invoke(ctx, ValuationsService_GetOwnerValuations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ValuationsServiceServer is the server API for ValuationsService service.
 // All implementations must embed UnimplementedValuationsServiceServer
 // for forward compatibility
 type ValuationsServiceServer interface {
+	// network value from the daily snapshot with growth and breakdowns
 	GetAllValuations(context.Context, *emptypb.Empty) (*ValuationResponse, error)
 	GetUserDeviceValuation(context.Context, *DeviceValuationRequest) (*DeviceValuation, error)
 	GetUserDeviceOffer(context.Context, *DeviceOfferRequest) (*DeviceOffer, error)
+	// same as GetAllValuations
 	GetAllUserDeviceValuation(context.Context, *emptypb.Empty) (*ValuationResponse, error)
+	// latest valuation of every vehicle owned by the address, with totals
+	GetOwnerValuations(context.Context, *OwnerValuationsRequest) (*OwnerValuations, error)
 	mustEmbedUnimplementedValuationsServiceServer()
 }
 
@@ -107,6 +125,9 @@ func (UnimplementedValuationsServiceServer) GetUserDeviceOffer(context.Context,
 func (UnimplementedValuationsServiceServer) GetAllUserDeviceValuation(context.Context, *emptypb.Empty) (*ValuationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllUserDeviceValuation not implemented")
 }
+func (UnimplementedValuationsServiceServer) GetOwnerValuations(context.Context, *OwnerValuationsRequest) (*OwnerValuations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOwnerValuations not implemented")
+}
 func (UnimplementedValuationsServiceServer) mustEmbedUnimplementedValuationsServiceServer() {}
 
 // UnsafeValuationsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -192,6 +213,24 @@ func _ValuationsService_GetAllUserDeviceValuation_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ValuationsService_GetOwnerValuations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OwnerValuationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ValuationsServiceServer).GetOwnerValuations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ValuationsService_GetOwnerValuations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ValuationsServiceServer).GetOwnerValuations(ctx, req.(*OwnerValuationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ValuationsService_ServiceDesc is the grpc.ServiceDesc for ValuationsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -215,6 +254,10 @@ var ValuationsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllUserDeviceValuation",
 			Handler:    _ValuationsService_GetAllUserDeviceValuation_Handler,
 		},
+		{
+			MethodName: "GetOwnerValuations",
+			Handler:    _ValuationsService_GetOwnerValuations_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/grpc/valuations.proto",